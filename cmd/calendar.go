@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"clingo/events"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newCalendar(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar [month] [year]",
+		Short: "Month calendar of events",
+		Long: "Show the month grid with the days having events marked and the events of the month listed by type, " +
+			"the current month by default; the month is a number or a name, e.g. 3 or March",
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := reportedEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			year, month := conf.Today.Year(), conf.Today.Month()
+			if len(args) > 0 {
+				if month, err = parseMonth(args[0]); err != nil {
+					return err
+				}
+			}
+			if len(args) > 1 {
+				if year, err = strconv.Atoi(args[1]); err != nil || year < 1 {
+					return fmt.Errorf(`invalid year "%s"`, args[1])
+				}
+			}
+			return events.RunCalendar(cmd.OutOrStdout(), list, conf, year, month)
+		},
+	}
+
+	return cmd
+}
+
+// parseMonth is a function to parse a month given by its number (1-12) or its English name, at least 3 letters of it
+func parseMonth(value string) (time.Month, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		if n >= 1 && n <= 12 {
+			return time.Month(n), nil
+		}
+	} else if len(value) >= 3 {
+		for m := time.January; m <= time.December; m++ {
+			if strings.HasPrefix(strings.ToLower(m.String()), strings.ToLower(value)) {
+				return m, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf(`invalid month "%s", expected 1-12 or a month name`, value)
+}
@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"clingo/constants"
+	"clingo/events"
+	"clingo/helpers"
+	"clingo/holidays"
+	"clingo/structs"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newEvents(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Manage events",
+		Long:  "Manage events of the events file",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newEventsList(conf),
+		newEventsAdd(conf),
+		newEventsEdit(conf),
+		newEventsRemove(conf),
+		newEventsExport(conf),
+		newEventsLint(conf),
+		newEventsFind(conf),
+		newEventsAck(conf),
+		newEventsSnooze(conf),
+		newEventsImport(conf),
+		newEventsGreet(conf),
+		newEventsStats(conf),
+	)
+
+	return cmd
+}
+
+func newEventsExport(conf *events.ConfigEvents) *cobra.Command {
+	format := ""
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export events",
+		Long:  "Export events of the events file in the given format, e.g. as an iCalendar feed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := loadEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			return events.Export(cmd.OutOrStdout(), list, format, conf)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "ics", "export format (ics)")
+
+	return cmd
+}
+
+func newEventsFind(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find <text>",
+		Short: "Find events",
+		Long:  "Find the events whose description or type contains the text and show when they occur next",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := reportedEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return events.RunFind(cmd.OutOrStdout(), list, conf, strings.Join(args, " "), true)
+		},
+	}
+
+	return cmd
+}
+
+func newEventsGreet(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "greet [text]",
+		Short: "Greetings for today's events",
+		Long: "Write ready-to-send greetings for today's events, or only those whose description or type " +
+			"contains the text, with the greeting templates by event type",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := reportedEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return events.RunGreet(cmd.OutOrStdout(), list, conf, strings.Join(args, " "))
+		},
+	}
+
+	return cmd
+}
+
+func newEventsStats(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Events statistics",
+		Long: "Summarise the events files: events per type and per month, the busiest weeks of the year, " +
+			"the next events, events without a year and the ages of birthdays",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := loadEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			return events.RunStats(cmd.OutOrStdout(), list, conf)
+		},
+	}
+
+	return cmd
+}
+
+func newEventsImport(conf *events.ConfigEvents) *cobra.Command {
+	remind := structs.Remind{Days: 3}
+
+	cmd := &cobra.Command{
+		Use:   "import <file.vcf|file.ics>...",
+		Short: "Import events from contacts or calendars",
+		Long: "Import the birthdays and anniversaries of the contacts of vCard files, or the events of iCalendar files, " +
+			"into the events file, events it has already are kept as they are",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := conf.File()
+			if err != nil {
+				return err
+			}
+			list, err := events.Open(path)
+			if err != nil {
+				return err
+			}
+			var imported []structs.EventMetadata
+			for _, arg := range args {
+				content, err := os.ReadFile(arg)
+				if err != nil {
+					return err
+				}
+				// iCalendar events are reminded of as their alarms tell
+				if events.Format(arg) == events.FormatICS {
+					calendar, err := events.ParseICS(content)
+					for i := 0; err == nil && i < len(calendar); i++ {
+						err = events.Validate(calendar[i])
+					}
+					if err != nil {
+						return fmt.Errorf(`calendar file "%s": %s`, arg, err)
+					}
+					imported = append(imported, calendar...)
+					continue
+				}
+				contacts, err := events.ParseVCF(content, remind)
+				if err != nil {
+					return fmt.Errorf(`contacts file "%s": %s`, arg, err)
+				}
+				imported = append(imported, contacts...)
+			}
+			list, added := events.Import(list, imported)
+			if added > 0 {
+				if err = events.Save(path, list); err != nil {
+					return err
+				}
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Imported %d event(s), %d already in %s\n",
+				added, len(imported)-added, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().Var(&remind, "remind", "days to remind in advance of the events imported from contacts, e.g. 3 (every day) or 30,7,1 (on these days)")
+
+	return cmd
+}
+
+func newEventsList(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List events",
+		Long:  "List events of the events files sorted by calendar day, the numbers are used to edit or remove events",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := loadEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			events.Sort(list)
+			output := ""
+			for i, e := range list {
+				if conf.Filter == "" || e.Type == conf.Filter {
+					output += fmt.Sprintf("%3d. %s\n", i+1, events.Describe(e))
+				}
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), "", output)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newEventsAdd(conf *events.ConfigEvents) *cobra.Command {
+	var e structs.EventMetadata
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add an event",
+		Long:  "Add an event to the events file: on a fixed day, with a recurrence rule, once or as a range of days",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := events.Validate(e); err != nil {
+				return err
+			}
+			path, err := conf.File()
+			if err != nil {
+				return err
+			}
+			list, err := events.Open(path)
+			if err != nil {
+				return err
+			}
+			if err = events.Save(path, append(list, e)); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Added %s\n", events.Describe(e))
+			return nil
+		},
+	}
+
+	bindEventFlags(cmd.Flags(), &e)
+
+	return cmd
+}
+
+func newEventsEdit(conf *events.ConfigEvents) *cobra.Command {
+	var changes structs.EventMetadata
+
+	cmd := &cobra.Command{
+		Use:   "edit <number>",
+		Short: "Edit an event",
+		Long:  "Change the given fields of the event with the number shown by 'events list'",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := conf.File()
+			if err != nil {
+				return err
+			}
+			list, i, err := openAt(path, args[0])
+			if err != nil {
+				return err
+			}
+			e := list[i]
+			flags := cmd.Flags()
+			if flags.Changed("day") {
+				e.Date, e.Recurrence, e.Start, e.End = changes.Date, "", "", ""
+			}
+			if flags.Changed("recurrence") {
+				e.Date, e.Recurrence, e.Start, e.End = "", changes.Recurrence, "", ""
+			}
+			if flags.Changed("start") {
+				e.Date, e.Recurrence, e.Start = "", "", changes.Start
+			}
+			if flags.Changed("end") {
+				e.End = changes.End
+			}
+			if flags.Changed("year") {
+				e.Year = changes.Year
+			}
+			if flags.Changed("remind") {
+				e.Remind = changes.Remind
+			}
+			if flags.Changed("type") {
+				e.Type = changes.Type
+			}
+			if flags.Changed("event") {
+				e.Event = changes.Event
+			}
+			if flags.Changed("calendar") {
+				e.Calendar = changes.Calendar
+			}
+			if flags.Changed("observe") {
+				e.Observe = changes.Observe
+			}
+			if flags.Changed("business-days") {
+				e.BusinessDays = changes.BusinessDays
+			}
+			if flags.Changed("tz") {
+				e.TZ = changes.TZ
+			}
+			if err = events.Validate(e); err != nil {
+				return err
+			}
+			list[i] = e
+			if err = events.Save(path, list); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Updated %s\n", events.Describe(e))
+			return nil
+		},
+	}
+
+	bindEventFlags(cmd.Flags(), &changes)
+
+	return cmd
+}
+
+func newEventsRemove(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <number>",
+		Aliases: []string{"remove"},
+		Short:   "Remove an event",
+		Long:    "Remove the event with the number shown by 'events list'",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := conf.File()
+			if err != nil {
+				return err
+			}
+			list, i, err := openAt(path, args[0])
+			if err != nil {
+				return err
+			}
+			e := list[i]
+			if err = events.Save(path, append(list[:i], list[i+1:]...)); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", events.Describe(e))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newEventsLint(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check events",
+		Long:  "Check the events file for malformed JSON, duplicate keys, impossible dates, unknown types and other problems",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := conf.Resolve(time.Now()); err != nil {
+				return err
+			}
+			sources, err := conf.Sources()
+			if err != nil {
+				return err
+			}
+			output := ""
+			count := 0
+			for _, source := range sources {
+				// Years in the future are such as of the current date, whichever date events are evaluated for
+				problems := events.LintFile(source, time.Now())
+				for _, problem := range problems {
+					output += fmt.Sprintf("%s: %s\n", source, problem)
+				}
+				if len(problems) == 0 {
+					output += fmt.Sprintf("%s: no problems found\n", source)
+				}
+				count += len(problems)
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), "", output)
+			if count > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d problem(s) found in events files", count)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// loadEvents resolves the date of today and loads the events files once they have no problems,
+// these fail the command loudly instead of looking like a day without events
+func loadEvents(cmd *cobra.Command, conf *events.ConfigEvents) ([]structs.EventMetadata, error) {
+	if err := conf.Resolve(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := conf.Check(); err != nil {
+		return nil, err
+	}
+	sources, err := conf.Sources()
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, source := range sources {
+		for _, problem := range events.LintFile(source, time.Now()) {
+			problems = append(problems, fmt.Sprintf("%s: %s", source, problem))
+		}
+	}
+	if len(problems) > 0 {
+		cmd.SilenceUsage = true
+		return nil, fmt.Errorf("%d problem(s) found in events files, see 'clingo events lint':\n  %s",
+			len(problems), strings.Join(problems, "\n  "))
+	}
+	return events.LoadAll(sources)
+}
+
+// reportedEvents is a function to load the events to report: the public holidays of the countries configured
+// merged with the events files, which take precedence over them.
+// Holidays can be reported without any events file: the default one is then skipped if missing.
+func reportedEvents(cmd *cobra.Command, conf *events.ConfigEvents) ([]structs.EventMetadata, error) {
+	list, err := holidays.Events(conf.Holidays)
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Holidays) > 0 && len(conf.Paths) == 1 && conf.Paths[0] == constants.EventsDefaultJSONFilePath {
+		if _, err = os.Stat(conf.Paths[0]); os.IsNotExist(err) {
+			conf.Paths = nil
+		}
+	}
+	details, err := loadEvents(cmd, conf)
+	if err != nil {
+		return nil, err
+	}
+	return events.Merge(list, details), nil
+}
+
+// openAt loads the events file for changes and converts the event number shown by 'events list' into an index
+func openAt(path string, number string) ([]structs.EventMetadata, int, error) {
+	list, err := events.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 || n > len(list) {
+		return nil, 0, fmt.Errorf(`no event number "%s" in "%s", see 'events list'`, number, path)
+	}
+	return list, n - 1, nil
+}
+
+func bindEventFlags(flags *pflag.FlagSet, e *structs.EventMetadata) {
+	flags.StringVar(&e.Date, "day", "", "event day as MM-DD")
+	flags.StringVar(&e.Recurrence, "recurrence", "", "event recurrence rule, e.g. \"2nd Sunday of May\"")
+	flags.StringVar(&e.Start, "start", "", "date of a one-off event or the first day of a range as YYYY-MM-DD")
+	flags.StringVar(&e.End, "end", "", "last day of a range as YYYY-MM-DD")
+	flags.IntVar(&e.Year, "year", 0, "event year")
+	flags.Var(&e.Remind, "remind", "days to remind in advance, e.g. 3 (every day) or 30,7,1 (on these days)")
+	flags.StringVar(&e.Type, "type", "", "event type (anniversary, birthday, holiday)")
+	flags.StringVar(&e.Event, "event", "", "event description")
+	flags.StringVar(&e.Calendar, "calendar", "",
+		fmt.Sprintf("calendar of the event day (%s), Gregorian by default", strings.Join(helpers.Calendars, ", ")))
+	flags.StringVar(&e.Observe, "observe", "",
+		fmt.Sprintf("weekday to observe the event falling on a weekend on (%s), none by default", strings.Join(helpers.Observances, ", ")))
+	flags.BoolVar(&e.BusinessDays, "business-days", false, "count the days to remind in advance in business days")
+	flags.StringVar(&e.TZ, "tz", "", "time zone of the event (IANA name, e.g. Asia/Tokyo), the one of today by default")
+
+	// Fields of the event are given with flags only, e.g. --tz is not the time zone of today of the config file
+	flags.VisitAll(func(f *pflag.Flag) {
+		_ = flags.SetAnnotation(f.Name, eventFieldAnnotation, []string{"true"})
+	})
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"clingo/constants"
+	"clingo/events"
 	"clingo/helpers"
-	"encoding/json"
+	"clingo/holidays"
+	"encoding/csv"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -20,15 +22,10 @@ const (
 	// The environment variable prefix of all environment variables bound to our command line flags.
 	// For example, --number is bound to CLINGO_NUMBER.
 	envPrefix = "CLINGO"
-)
 
-// EventMetadata is a struct to store metadata about a personal or public event
-type EventMetadata struct {
-	Year   int    `json:"year"`
-	Remind int    `json:"remind"`
-	Type   string `json:"type"`
-	Event  string `json:"event"`
-}
+	// The annotation of flags giving the fields of an event, they are not bound to the config file or environment variables.
+	eventFieldAnnotation = "clingo_event_field"
+)
 
 // NewRootCommand builds the cobra command that handles our command line tool.
 func NewRootCommand() *cobra.Command {
@@ -38,54 +35,45 @@ func NewRootCommand() *cobra.Command {
 	// not recommended that you use one-off variables. The point is that we
 	// aren't retrieving the values directly from viper or flags, we read the values
 	// from standard Go data structures.
-	events := ""
-	filter := ""
-	output := ""
+	var conf events.ConfigEvents
 
 	// Define our command
 	rootCmd := &cobra.Command{
 		Use:   "clingo",
 		Short: "Check if today is a special day",
 		Long:  `Based on a JSON file, check if today is a special day`,
+		// Errors are printed once by the caller of Execute
+		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// You can bind cobra and viper in a few locations, but PersistencePreRunE on the root command works well
 			return initializeConfig(cmd)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			content := helpers.ReadJSON(events)
-			var details map[string]EventMetadata
-			err := json.Unmarshal(content, &details)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := reportedEvents(cmd, &conf)
 			if err != nil {
-				fmt.Printf(`Error loading JSON from "%s": %s`, constants.EventsDefaultJSONFilePath, err)
+				return err
 			}
-			today := time.Now()
-			// today = time.Date(2022, time.March, 26, 23, 12, 5, 3, time.UTC)
-
-			dt := helpers.GetMonthDay(today, 0)
-			if _, ok := details[dt]; ok && (filter == "" || details[dt].Type == filter) {
-				output += fmt.Sprintf("Today is %d %s %d: %s [%d year(s)]\n",
-					today.Day(), today.Month(), today.Year(),
-					details[dt].Event, today.Year()-details[dt].Year)
+			// Reporting events does not depend on the reminder state, it is only warned about if unavailable
+			path, stateErr := loadState(&conf)
+			if stateErr != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: reminder state is not used: %s\n", stateErr)
+				conf.State = &events.State{}
 			}
 
-			// Now scan for the upcoming events with reminders
-			for i := 1; i < 10; i++ {
-				dt = helpers.GetMonthDay(today, i)
-				if _, ok := details[dt]; ok {
-					if i <= details[dt].Remind && (filter == "" || details[dt].Type == filter) {
-						output += fmt.Sprintf("In %d day(s) will be %d-%s: %s [%d year(s)]\n",
-							i, today.Year(), dt, details[dt].Event, today.Year()-details[dt].Year)
-					}
-				}
-			}
-			if output == "" {
-				output = "No events today.\nNo reminders today.\n"
-			}
 			// Working with OutOrStdout/OutOrStderr allows us to unit test our command easier
-			out := cmd.OutOrStdout()
+			if err = events.Run(cmd.OutOrStdout(), list, &conf); err != nil {
+				return err
+			}
 
-			// Print the final resolved value from binding cobra flags and viper config
-			_, _ = fmt.Fprint(out, "", output)
+			// Runs as of another date or of some events only do not count as checks of today's events
+			if conf.Date != "" || conf.Filter != "" || stateErr != nil {
+				return nil
+			}
+			conf.State.LastRun = conf.Today.Format("2006-01-02")
+			if err = conf.State.Save(path, conf.Today); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: reminder state is not saved: %s\n", err)
+			}
+			return nil
 		},
 	}
 
@@ -94,19 +82,41 @@ func NewRootCommand() *cobra.Command {
 	// then env var CLINGO_EVENTS,
 	// then the config file,
 	// then the default last.
-	rootCmd.Flags().StringVarP(&events, "events", "e", constants.EventsDefaultJSONFilePath, "Is today a special day?")
-	rootCmd.Flags().StringVarP(&filter, "filter", "f", "", "Filter events by type")
+	bindEventsFlags(rootCmd.PersistentFlags(), &conf)
 
 	rootCmd.AddCommand(
 		newWeather(),
 		newCurrency(),
 		newJokes(),
 		newNews(),
+		newEvents(&conf),
+		newCalendar(&conf),
+		newUntil(&conf),
 	)
 
 	return rootCmd
 }
 
+func bindEventsFlags(flags *pflag.FlagSet, config *events.ConfigEvents) {
+	flags.StringSliceVarP(&config.Paths, "events", "e", []string{constants.EventsDefaultJSONFilePath},
+		"Is today a special day? Events files or directories of them, repeat or separate with commas to merge them")
+	flags.StringSliceVar(&config.Holidays, "holidays", nil,
+		fmt.Sprintf("Report public holidays of the countries, e.g. NL,US (%s)", strings.Join(holidays.Countries(), ", ")))
+	flags.StringVarP(&config.Filter, "filter", "f", "", "Filter events by type")
+	flags.IntVar(&config.Horizon, "horizon", 30, "Number of days to scan ahead for reminders")
+	flags.StringVar(&config.LeapDay, "leap-day", helpers.LeapDayFeb28,
+		"Observe events of February 29 in non-leap years on: feb28, mar1 or skip (leap years only)")
+	flags.StringVar(&config.Date, "date", "", "Evaluate events as of the given date (YYYY-MM-DD) instead of today")
+	flags.StringVar(&config.TZ, "tz", "", "Time zone (IANA name, e.g. Europe/Amsterdam) to tell the date of today, local by default")
+	flags.StringSliceVar(&config.Milestones, "milestones", nil,
+		"Milestone years of events reminded of earlier, e.g. 1,5,25,50,%10 (%10 for every multiple of 10)")
+	flags.IntVar(&config.MilestoneRemind, "milestone-remind", 30, "Number of days to remind of milestones in advance at least")
+	flags.StringToStringVar(&config.Templates, "template", nil,
+		"Go templates of report lines by event type, e.g. birthday='{{.Name}} turns {{.Age}} in {{.DaysUntil}} day(s)'")
+	flags.StringToStringVar(&config.Greetings, "greeting", nil,
+		"Go templates of greetings by event type, e.g. birthday='Happy {{.Ordinal}} birthday, {{.Name}}!'")
+}
+
 func initializeConfig(cmd *cobra.Command) error {
 	v := viper.New()
 
@@ -147,6 +157,9 @@ func initializeConfig(cmd *cobra.Command) error {
 // Bind each cobra flag to its associated viper configuration (config file and environment variable)
 func bindFlags(cmd *cobra.Command, v *viper.Viper) {
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if _, ok := f.Annotations[eventFieldAnnotation]; ok {
+			return
+		}
 		// Environment variables can't have dashes in them, so bind them to their equivalent
 		// keys with underscores, e.g. --favorite-color to CLINGO_FAVORITE_COLOR
 		if strings.Contains(f.Name, "-") {
@@ -160,6 +173,32 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) {
 		// Apply the viper config value to the flag when the flag is not set and viper has a value
 		if !f.Changed && v.IsSet(f.Name) {
 			val := v.Get(f.Name)
+			// Lists of the config file are given to list flags as comma-separated values
+			if list, ok := val.([]interface{}); ok {
+				items := make([]string, len(list))
+				for i, item := range list {
+					items[i] = fmt.Sprintf("%v", item)
+				}
+				val = strings.Join(items, ",")
+			}
+			// Tables of the config file are given to map flags pair by pair, map flags read a pair
+			// with more than one "=" as CSV, so such pairs are quoted as CSV
+			if table, ok := val.(map[string]interface{}); ok {
+				for key, item := range table {
+					pair := fmt.Sprintf("%s=%v", key, item)
+					if strings.Count(pair, "=") > 1 {
+						var b bytes.Buffer
+						w := csv.NewWriter(&b)
+						_ = w.Write([]string{pair})
+						w.Flush()
+						pair = strings.TrimSuffix(b.String(), "\n")
+					}
+					if err := cmd.Flags().Set(f.Name, pair); err != nil {
+						return
+					}
+				}
+				return
+			}
 			err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val))
 			if err != nil {
 				return
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,9 @@ func TestPrecedence(t *testing.T) {
 		require.NoError(t, e2, fmt.Sprintf("error removing temporary test folder %s", tmpDir))
 	}()
 
+	// Keep acknowledged and snoozed reminders of the tests apart from those of the user
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
 	testDir, e3 := os.Getwd()
 	require.NoError(t, e3, "error getting the current working directory")
 
@@ -49,8 +53,9 @@ func TestPrecedence(t *testing.T) {
 		}
 	}(f)
 	today := time.Now()
+	tomorrow := today.AddDate(0, 0, 1)
 	s := fmt.Sprintf("{\"%02d-%02d\": {\"year\": 2000, \"remind\": 3, \"type\": \"birthday\", \"event\": \"Someone's birthday\"},\"%02d-%02d\": {\"year\": 2000, \"remind\": 3, \"type\": \"anniversary\", \"event\": \"Someone's aniversary\"}}",
-		today.Month(), today.Day(), today.Month(), today.Day()+1)
+		today.Month(), today.Day(), tomorrow.Month(), tomorrow.Day())
 	_, e8 := f.WriteString(s)
 	require.NoError(t, e8, fmt.Sprintf("failed to write file %s due to %s", eventsFileName, e8))
 
@@ -79,10 +84,35 @@ func TestPrecedence(t *testing.T) {
 
 		gotOutput := output.String()
 		wantOutput := fmt.Sprintf("Today is %d %s %d: Someone's birthday [%d year(s)]\nIn 1 day(s) will be %d-%02d-%02d: Someone's aniversary [%d year(s)]\n",
-			today.Day(), today.Month(), today.Year(), today.Year()-2000, today.Year(), today.Month(), today.Day()+1, today.Year()-2000)
+			today.Day(), today.Month(), today.Year(), today.Year()-2000, tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), tomorrow.Year()-2000)
 		assert.Equal(t, wantOutput, gotOutput, "expected the 'events' option from the config file and the 'filter' from the flag default")
 	})
 
+	// Set templates of greetings with a table of the config file
+	t.Run("config file table", func(t *testing.T) {
+		writePath := filepath.Join(tmpDir, "clingo-conf.toml")
+		config := "[greeting]\nbirthday = \"Happy birthday, {{.Name}}! Age={{.Age}}\"\n"
+		e9 := ioutil.WriteFile(writePath, []byte(config), 0644)
+		require.NoError(t, e9, fmt.Sprintf("error writing test config file %s", writePath))
+
+		defer func(name string) {
+			e10 := os.Remove(name)
+			require.NoError(t, e10, fmt.Sprintf("error removing test config file %s", name))
+		}(writePath)
+
+		// Run ./clingo events greet
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"events", "greet"})
+		err := cmd.Execute()
+		require.NoError(t, err, fmt.Sprintf("error executing cli command %s", err))
+
+		gotOutput := output.String()
+		wantOutput := fmt.Sprintf("Happy birthday, Someone! Age=%d\n", today.Year()-2000)
+		assert.Equal(t, wantOutput, gotOutput, "expected the greeting template from the config file")
+	})
+
 	// Set favorite-color with an environment variable
 	t.Run("env var", func(t *testing.T) {
 		// Run CLINGO=purple ./clingo
@@ -102,7 +132,7 @@ func TestPrecedence(t *testing.T) {
 
 		gotOutput := output.String()
 		wantOutput := fmt.Sprintf("In 1 day(s) will be %d-%02d-%02d: Someone's aniversary [%d year(s)]\n",
-			today.Year(), today.Month(), today.Day()+1, today.Year()-2000)
+			tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), tomorrow.Year()-2000)
 		assert.Equal(t, wantOutput, gotOutput, "expected the 'filter' option to use the environment variable value and the 'events' option to use the flag default")
 	})
 
@@ -121,4 +151,179 @@ func TestPrecedence(t *testing.T) {
 			today.Day(), today.Month(), today.Year(), today.Year()-2000)
 		assert.Equal(t, wantOutput, gotOutput, "expected the 'filter' option to use the flag value and 'events' option to use the flag default")
 	})
+
+	// Merge events files given with a repeated flag
+	t.Run("repeated events flag", func(t *testing.T) {
+		holidaysFileName := filepath.Join(tmpDir, "holidays.json")
+		s := fmt.Sprintf("[{\"date\": \"%02d-%02d\", \"year\": 1, \"remind\": 0, \"type\": \"holiday\", \"event\": \"Some holiday\"}]",
+			today.Month(), today.Day())
+		e9 := ioutil.WriteFile(holidaysFileName, []byte(s), 0644)
+		require.NoError(t, e9, fmt.Sprintf("error writing events file %s", holidaysFileName))
+
+		defer func(name string) {
+			e10 := os.Remove(name)
+			require.NoError(t, e10, fmt.Sprintf("error removing events file %s", name))
+		}(holidaysFileName)
+
+		// Run ./clingo --events events.json --events holidays.json --horizon 0
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"--events", "events.json", "--events", "holidays.json", "--horizon", "0"})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		gotOutput := output.String()
+		wantOutput := fmt.Sprintf("Today is %d %s %d: Someone's birthday [%d year(s)] (events.json)\nToday is %d %s %d: Some holiday [%d year(s)] (holidays.json)\n",
+			today.Day(), today.Month(), today.Year(), today.Year()-2000, today.Day(), today.Month(), today.Year(), today.Year()-1)
+		assert.Equal(t, wantOutput, gotOutput, "expected the events of both files labelled with their file")
+	})
+
+	// Report public holidays of a country with a flag
+	t.Run("holidays flag", func(t *testing.T) {
+		// Run ./clingo --holidays nl --date 2025-04-25 --filter holiday
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"--holidays", "nl", "--date", "2025-04-25", "--filter", "holiday"})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		gotOutput := output.String()
+		wantOutput := "In 1 day(s) will be 2025-04-26: King's Day [11 year(s)] (NL holidays)\n"
+		assert.Equal(t, wantOutput, gotOutput, "expected King's Day moved from Sunday to Saturday")
+	})
+
+	// Report public holidays without any events file
+	t.Run("holidays without events file", func(t *testing.T) {
+		// Run ./clingo --holidays nl --date 2025-04-25 in a directory without events.json
+		dir, err := os.Getwd()
+		require.NoError(t, err, "error getting the current working directory")
+		require.NoError(t, os.Chdir(t.TempDir()), "error changing to an empty directory")
+		defer func() {
+			require.NoError(t, os.Chdir(dir), fmt.Sprintf("error changing working directory to %s", dir))
+		}()
+
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"--holidays", "nl", "--date", "2025-04-25"})
+		err = cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		gotOutput := output.String()
+		wantOutput := "In 1 day(s) will be 2025-04-26: King's Day [11 year(s)] (NL holidays)\n"
+		assert.Equal(t, wantOutput, gotOutput, "expected the holidays to be reported without events")
+	})
+
+	// Evaluate events as of yesterday with a flag
+	t.Run("date flag", func(t *testing.T) {
+		// Run ./clingo --date <yesterday>
+		yesterday := today.AddDate(0, 0, -1)
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"--date", yesterday.Format("2006-01-02")})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		gotOutput := output.String()
+		wantOutput := fmt.Sprintf("In 1 day(s) will be %s: Someone's birthday [%d year(s)]\nIn 2 day(s) will be %s: Someone's aniversary [%d year(s)]\n",
+			today.Format("2006-01-02"), today.Year()-2000, tomorrow.Format("2006-01-02"), tomorrow.Year()-2000)
+		assert.Equal(t, wantOutput, gotOutput, "expected the events to be evaluated as of the 'date' option")
+	})
+
+	// Events of later years than the date option are not problems of the events file
+	t.Run("date flag before the year of an event", func(t *testing.T) {
+		// Run ./clingo --date 1999-01-01
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--date", "1999-01-01"})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+	})
+
+	// Set the time zone of an added event, not taken from the time zone of today
+	t.Run("add with time zone", func(t *testing.T) {
+		t.Setenv("CLINGO_TZ", "Europe/Amsterdam")
+		path := filepath.Join(t.TempDir(), "events.json")
+		for _, args := range [][]string{
+			{"events", "add", "--events", path, "--day", "05-01", "--type", "holiday", "--event", "Labour Day"},
+			{"events", "add", "--events", path, "--day", "05-03", "--type", "holiday", "--event", "Constitution Day", "--tz", "Asia/Tokyo"},
+		} {
+			cmd := NewRootCommand()
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetArgs(args)
+			require.NoError(t, cmd.Execute(), "error executing cli command")
+		}
+
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"events", "edit", "1", "--events", path, "--tz", "Mars/Olympus_Mons"})
+		require.Error(t, cmd.Execute(), "expected an unknown time zone to be rejected")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "error reading the events file")
+		assert.Equal(t, 1, strings.Count(string(content), `"tz"`), "expected the time zone of the second event only")
+		assert.Contains(t, string(content), `"tz":"Asia/Tokyo"`, "expected the time zone of the event")
+	})
+
+	// Report events even without the reminder state
+	t.Run("no state", func(t *testing.T) {
+		// Run ./clingo with neither HOME nor XDG_STATE_HOME defined
+		t.Setenv("HOME", "")
+		t.Setenv("XDG_STATE_HOME", "")
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		errOutput := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetErr(errOutput)
+		cmd.SetArgs([]string{})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		assert.Contains(t, output.String(), "Someone's birthday", "expected the events to be reported")
+		assert.Contains(t, errOutput.String(), "warning: reminder state is not used", "expected a warning")
+	})
+
+	// Report events even if the reminder state cannot be saved
+	t.Run("read-only state", func(t *testing.T) {
+		// Run ./clingo with XDG_STATE_HOME not writable
+		t.Setenv("XDG_STATE_HOME", "/proc/none")
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		errOutput := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetErr(errOutput)
+		cmd.SetArgs([]string{})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		assert.Contains(t, output.String(), "Someone's birthday", "expected the events to be reported")
+		assert.Contains(t, errOutput.String(), "warning: reminder state is not saved", "expected a warning")
+	})
+
+	// Acknowledge and snooze reminders, hidden from the following runs
+	t.Run("ack and snooze", func(t *testing.T) {
+		for _, args := range [][]string{{"events", "ack", "birthday"}, {"events", "snooze", "2d", "aniversary"}} {
+			// Run ./clingo events ack birthday, then ./clingo events snooze 2d aniversary
+			cmd := NewRootCommand()
+			output := &bytes.Buffer{}
+			cmd.SetOut(output)
+			cmd.SetArgs(args)
+			require.NoError(t, cmd.Execute(), "error executing cli command")
+			assert.Contains(t, output.String(), args[len(args)-1], "expected the changed reminder")
+		}
+
+		cmd := NewRootCommand()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{})
+		err := cmd.Execute()
+		require.NoError(t, err, "error executing cli command")
+
+		gotOutput := output.String()
+		wantOutput := "No events today.\nNo reminders today.\n"
+		assert.Equal(t, wantOutput, gotOutput, "expected the acknowledged and snoozed reminders to be hidden")
+	})
 }
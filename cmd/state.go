@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"clingo/events"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newEventsAck(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ack [text]",
+		Short: "Acknowledge reminders",
+		Long: "Acknowledge the reminders reported today, or only those of the events whose description or type " +
+			"contains the text, so that they are hidden until the events occur",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateState(cmd, conf, strings.Join(args, " "), func(s *events.State, o events.Occurrence) string {
+				s.Ack(o, conf.TodayFor(o.Event))
+				return "Acknowledged"
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newEventsSnooze(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snooze <duration> [text]",
+		Short: "Snooze reminders",
+		Long: "Hide the reminders reported today, or only those of the events whose description or type " +
+			"contains the text, for the duration in days or weeks, e.g. 2d or 1w",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			days, err := parseDays(args[0])
+			if err != nil {
+				return err
+			}
+			return updateState(cmd, conf, strings.Join(args[1:], " "), func(s *events.State, o events.Occurrence) string {
+				until := conf.TodayFor(o.Event).AddDate(0, 0, days)
+				s.Snooze(o, until)
+				return "Snoozed until " + until.Format("2006-01-02")
+			})
+		},
+	}
+
+	return cmd
+}
+
+// updateState is a function to apply the change to the state for every occurrence reported today
+// which is not hidden yet and matches the text, if any, and to save the state
+func updateState(cmd *cobra.Command, conf *events.ConfigEvents, text string,
+	change func(*events.State, events.Occurrence) string) error {
+	list, err := reportedEvents(cmd, conf)
+	if err != nil {
+		return err
+	}
+	path, err := loadState(conf)
+	if err != nil {
+		return err
+	}
+	output := ""
+	for _, o := range events.Reported(list, conf) {
+		if conf.State.Hides(o, conf.TodayFor(o.Event)) || (text != "" && !events.Matches(o.Event, text)) {
+			continue
+		}
+		output += fmt.Sprintf("%s: %s (%s)\n", change(conf.State, o), o.Event.Event, o.Date.Format("2006-01-02"))
+	}
+	if output == "" {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("no reminders reported today to change")
+	}
+	if err = conf.State.Save(path, conf.Today); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprint(cmd.OutOrStdout(), "", output)
+	return nil
+}
+
+// loadState is a function to load the state of reminders into the configuration and give the path to its file
+func loadState(conf *events.ConfigEvents) (string, error) {
+	path, err := events.StatePath()
+	if err != nil {
+		return "", err
+	}
+	conf.State, err = events.LoadState(path)
+	return path, err
+}
+
+// parseDays is a function to parse a duration given in days (e.g. "2d" or "2") or weeks (e.g. "1w")
+func parseDays(value string) (int, error) {
+	unit := 1
+	number := value
+	switch {
+	case strings.HasSuffix(value, "d"):
+		number = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		number, unit = strings.TrimSuffix(value, "w"), 7
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf(`invalid duration "%s", expected days or weeks, e.g. 2d or 1w`, value)
+	}
+	return n * unit, nil
+}
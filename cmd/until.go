@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"clingo/events"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newUntil(conf *events.ConfigEvents) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "until <text>",
+		Short: "Days until an event",
+		Long:  "Count the days until the next event whose description or type contains the text, e.g. Christmas",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := reportedEvents(cmd, conf)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return events.RunFind(cmd.OutOrStdout(), list, conf, strings.Join(args, " "), false)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"clingo/helpers"
+	"clingo/structs"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InMonth is a function to find the occurrences of events in the month of the year sorted by date:
+// an event recurring in the month occurs on every date it falls on, a range occurs once,
+// on the first of its days within the month
+func InMonth(list []structs.EventMetadata, year int, month time.Month, leapDay string) []Occurrence {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	var occurrences []Occurrence
+	for _, e := range list {
+		if e.Start != "" {
+			start, end, err := span(e)
+			if err != nil || end.Before(first) || start.After(last) {
+				continue
+			}
+			o := Occurrence{Event: e, Date: start}
+			if e.End != "" {
+				if start.Before(first) {
+					o.Date = first
+				}
+				o.Day, o.Length = helpers.DaysBetween(start, o.Date)+1, helpers.DaysBetween(start, end)+1
+			}
+			occurrences = append(occurrences, o)
+			continue
+		}
+		// Events of the next or the previous year may be observed in this one
+		for y := year - 1; y <= year+1; y++ {
+			for _, nominal := range nominalDates(e, y, leapDay) {
+				if o := observe(e, nominal); o.Date.Year() == year && o.Date.Month() == month {
+					occurrences = append(occurrences, o)
+				}
+			}
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+	return occurrences
+}
+
+// RunCalendar is a function to print the grid of the month (weeks start on Monday) with the days having events
+// marked with "*", followed by the events of the month grouped by type
+func RunCalendar(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents, year int, month time.Month) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	days := first.AddDate(0, 1, -1).Day()
+
+	marked := make(map[int]bool)
+	byType := make(map[string][]Occurrence)
+	for _, o := range InMonth(list, year, month, conf.LeapDay) {
+		if conf.Filter != "" && o.Event.Type != conf.Filter {
+			continue
+		}
+		for d := o.Date.Day(); d <= lastDay(o, days); d++ {
+			marked[d] = true
+		}
+		byType[o.Event.Type] = append(byType[o.Event.Type], o)
+	}
+
+	title := fmt.Sprintf("%s %d", month, year)
+	output := strings.Repeat(" ", (20-len(title))/2) + title + "\nMo Tu We Th Fr Sa Su\n"
+	line := strings.Repeat("   ", (int(first.Weekday())+6)%7)
+	for d := 1; d <= days; d++ {
+		marker := " "
+		if marked[d] {
+			marker = "*"
+		}
+		line += fmt.Sprintf("%2d%s", d, marker)
+		if first.AddDate(0, 0, d-1).Weekday() == time.Sunday || d == days {
+			output += strings.TrimRight(line, " ") + "\n"
+			line = ""
+		}
+	}
+
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	if len(types) == 0 {
+		output += "\nNo events this month.\n"
+	}
+	for _, t := range types {
+		output += fmt.Sprintf("\n%s:\n", t)
+		for _, o := range byType[t] {
+			day := fmt.Sprintf("%d", o.Date.Day())
+			detail := details(o)
+			if o.Length > 0 {
+				if end := lastDay(o, days); end > o.Date.Day() {
+					day += fmt.Sprintf("-%d", end)
+				}
+				detail = fmt.Sprintf(" [%d day(s)]", o.Length)
+			}
+			output += fmt.Sprintf("%7s  %s%s%s\n", day, o.Event.Event, detail, label(o.Event))
+		}
+	}
+
+	_, _ = fmt.Fprint(out, "", output)
+	return nil
+}
+
+// lastDay is a function to give the last day of the month the occurrence lasts until, given the days of the month
+func lastDay(o Occurrence, days int) int {
+	last := o.Date.Day()
+	if o.Length > 0 {
+		last += o.Length - o.Day
+	}
+	if last > days {
+		return days
+	}
+	return last
+}
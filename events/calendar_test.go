@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"testing"
+	"time"
+)
+
+func TestRunCalendar(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Recurrence: "last Friday monthly", Year: 2020, Type: "anniversary", Event: "Team retro"},
+		{Start: "2026-02-27", End: "2026-03-03", Type: "holiday", Event: "Ski trip"},
+		{Start: "2026-03-30", End: "2026-04-03", Type: "holiday", Event: "Spring break"},
+		{Date: "02-29", Year: 2000, Type: "birthday", Event: "Leap day birthday"},
+	}
+
+	tests := []struct {
+		name    string
+		month   time.Month
+		filter  string
+		leapDay string
+		wantOut string
+	}{
+		{
+			"events of the month by type",
+			time.March,
+			"",
+			helpers.LeapDayFeb28,
+			"     March 2026\n" +
+				"Mo Tu We Th Fr Sa Su\n" +
+				"                   1*\n" +
+				" 2* 3* 4  5  6  7  8\n" +
+				" 9 10 11 12 13 14*15\n" +
+				"16 17 18 19 20 21 22\n" +
+				"23 24 25 26 27*28 29\n" +
+				"30*31*\n" +
+				"\nanniversary:\n" +
+				"     27  Team retro [6 year(s)]\n" +
+				"\nbirthday:\n" +
+				"     14  Someone's birthday [26 year(s)]\n" +
+				"\nholiday:\n" +
+				"    1-3  Ski trip [5 day(s)]\n" +
+				"  30-31  Spring break [5 day(s)]\n",
+		},
+		{
+			"filter and leap day policy",
+			time.February,
+			"birthday",
+			helpers.LeapDayMar1,
+			"   February 2026\n" +
+				"Mo Tu We Th Fr Sa Su\n" +
+				"                   1\n" +
+				" 2  3  4  5  6  7  8\n" +
+				" 9 10 11 12 13 14 15\n" +
+				"16 17 18 19 20 21 22\n" +
+				"23 24 25 26 27 28\n" +
+				"\nNo events this month.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := RunCalendar(out, list, &ConfigEvents{Filter: tt.filter, LeapDay: tt.leapDay}, 2026, tt.month)
+			if err != nil {
+				t.Errorf("RunCalendar() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("RunCalendar() got = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,500 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigEvents is a struct to keep input parameters required to report events
+type ConfigEvents struct {
+	Paths           []string
+	Holidays        []string
+	Filter          string
+	Horizon         int
+	LeapDay         string
+	Date            string
+	TZ              string
+	Today           time.Time
+	State           *State            // reminders acknowledged or snoozed, if any
+	Templates       map[string]string // templates of report lines by event type
+	Greetings       map[string]string // templates of greetings by event type
+	Milestones      []string          // numbers of years (e.g. "25") or their multiples (e.g. "%10") reminded of earlier
+	MilestoneRemind int               // days to remind of milestones in advance at least
+}
+
+// Occurrence is a struct to keep an event together with the date it happens on
+type Occurrence struct {
+	Event     structs.EventMetadata
+	Date      time.Time
+	Nominal   time.Time // the date before the observance rule of the event moves it off a weekend, ages are counted on
+	Days      int       // days from today until the date
+	Day       int       // day of the range the date is, counted from 1, zero for events of a single day
+	Length    int       // days of the range, zero for events of a single day
+	Milestone bool      // the event reaches one of the milestones on the date
+}
+
+// Resolve is a method to set the date events are evaluated for: the date given in YYYY-MM-DD format if any,
+// the current time otherwise. Both are taken in the time zone given by its IANA name, the local one by default.
+func (ce *ConfigEvents) Resolve(now time.Time) error {
+	loc := now.Location()
+	if ce.TZ != "" {
+		var err error
+		if loc, err = time.LoadLocation(ce.TZ); err != nil {
+			return fmt.Errorf(`unknown time zone "%s"`, ce.TZ)
+		}
+	}
+	if ce.Date == "" {
+		ce.Today = now.In(loc)
+		return nil
+	}
+	today, err := time.ParseInLocation("2006-01-02", ce.Date, loc)
+	if err != nil {
+		return fmt.Errorf(`invalid date "%s", expected YYYY-MM-DD`, ce.Date)
+	}
+	ce.Today = today
+	return nil
+}
+
+// TodayFor is a method to give the current time for the event: in the time zone of the event if it has one,
+// unless the date is given explicitly
+func (ce *ConfigEvents) TodayFor(e structs.EventMetadata) time.Time {
+	if e.TZ == "" || ce.Date != "" {
+		return ce.Today
+	}
+	loc, err := time.LoadLocation(e.TZ)
+	if err != nil {
+		return ce.Today
+	}
+	return ce.Today.In(loc)
+}
+
+// Check is a method to verify the input parameters have supported values and the templates are valid
+func (ce *ConfigEvents) Check() error {
+	if ce.Horizon < 0 {
+		return fmt.Errorf("horizon must not be negative, got %d", ce.Horizon)
+	}
+	if ce.MilestoneRemind < 0 {
+		return fmt.Errorf("milestone reminder must not be negative, got %d", ce.MilestoneRemind)
+	}
+	if err := checkMilestones(ce.Milestones); err != nil {
+		return err
+	}
+	known := false
+	for _, policy := range helpers.LeapDayPolicies {
+		known = known || ce.LeapDay == policy
+	}
+	if !known {
+		return fmt.Errorf(`unknown leap day policy "%s", expected one of %s`,
+			ce.LeapDay, strings.Join(helpers.LeapDayPolicies, ", "))
+	}
+	if err := checkTemplates("report", ce.Templates); err != nil {
+		return err
+	}
+	return checkTemplates("greeting", ce.Greetings)
+}
+
+// Load is a function to read the events file and return the list of events it contains,
+// the format is detected by the extension: YAML (".yaml", ".yml"), TOML (".toml"), CSV (".csv")
+// and iCalendar (".ics") files are recognised, JSON is expected otherwise.
+func Load(filePath string) ([]structs.EventMetadata, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	format := Format(filePath)
+	if format == FormatICS {
+		return ParseICS(content)
+	}
+	if content, err = toJSON(format, content); err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
+
+// Parse is a function to load events from JSON content, the schema is detected automatically:
+//   - a list of events, each event has its own "date" or "recurrence" field;
+//   - a map of "MM-DD" days to a list of events;
+//   - a map of "MM-DD" days to a single event (the original format).
+//
+// Map values of the last two formats may be mixed in the same file.
+// Events of the map formats are returned sorted by day, the order of the file is kept otherwise.
+func Parse(content []byte) ([]structs.EventMetadata, error) {
+	list, err := decode(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range list {
+		if err = checkSchedule(e); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// decode is a function to load events from JSON content in any of the formats Parse supports
+func decode(content []byte) ([]structs.EventMetadata, error) {
+	content = bytes.TrimSpace(content)
+	if len(content) > 0 && content[0] == '[' {
+		var list []structs.EventMetadata
+		if err := json.Unmarshal(content, &list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+	if len(content) == 0 || content[0] != '{' {
+		return nil, fmt.Errorf("unknown events format: expected a list of events or events grouped by day")
+	}
+
+	var days map[string]json.RawMessage
+	if err := json.Unmarshal(content, &days); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(days))
+	for key := range days {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var list []structs.EventMetadata
+	for _, key := range keys {
+		value := bytes.TrimSpace(days[key])
+		var items []structs.EventMetadata
+		if len(value) > 0 && value[0] == '[' {
+			if err := json.Unmarshal(value, &items); err != nil {
+				return nil, fmt.Errorf(`events of "%s": %s`, key, err)
+			}
+		} else {
+			var e structs.EventMetadata
+			if err := json.Unmarshal(value, &e); err != nil {
+				return nil, fmt.Errorf(`event of "%s": %s`, key, err)
+			}
+			items = append(items, e)
+		}
+		for _, e := range items {
+			if e.Recurrence != "" {
+				return nil, fmt.Errorf(`event "%s" of "%s" has a recurrence, use the list format instead`, e.Event, key)
+			}
+			e.Date = key
+			list = append(list, e)
+		}
+	}
+
+	return list, nil
+}
+
+// checkSchedule is a function to verify the event has exactly one of a day, a valid recurrence rule
+// in a supported calendar or a start date, which may be followed by an end date, and a supported observance rule
+func checkSchedule(e structs.EventMetadata) error {
+	if !helpers.KnownCalendar(e.Calendar) {
+		return fmt.Errorf(`event "%s" has unknown calendar "%s", expected one of %s`,
+			e.Event, e.Calendar, strings.Join(helpers.Calendars, ", "))
+	}
+	if !helpers.KnownObservance(e.Observe) {
+		return fmt.Errorf(`event "%s" has unknown observance rule "%s", expected one of %s`,
+			e.Event, e.Observe, strings.Join(helpers.Observances, ", "))
+	}
+	schedules := 0
+	for _, schedule := range []string{e.Date, e.Recurrence, e.Start} {
+		if schedule != "" {
+			schedules++
+		}
+	}
+	switch {
+	case schedules == 0:
+		return fmt.Errorf(`event "%s" has neither a date nor a recurrence nor a start`, e.Event)
+	case schedules > 1:
+		return fmt.Errorf(`event "%s" has more than one of a date, a recurrence and a start`, e.Event)
+	case e.End != "" && e.Start == "":
+		return fmt.Errorf(`event "%s" has an end but no start`, e.Event)
+	case e.Start != "":
+		if _, _, err := span(e); err != nil {
+			return fmt.Errorf(`event "%s" %s`, e.Event, err)
+		}
+		if e.Calendar != "" && e.Calendar != helpers.CalendarGregorian {
+			return fmt.Errorf(`event "%s" has a start, which is only supported in the gregorian calendar`, e.Event)
+		}
+		if e.Observe != "" && e.Observe != helpers.ObserveNone {
+			return fmt.Errorf(`event "%s" has a start, observance rules only apply to yearly events`, e.Event)
+		}
+	case e.Recurrence != "":
+		r, err := helpers.ParseRecurrence(e.Recurrence)
+		if err != nil {
+			return fmt.Errorf(`event "%s": %s`, e.Event, err)
+		}
+		if !r.Supports(e.Calendar) {
+			return fmt.Errorf(`event "%s" has recurrence "%s" not supported in the %s calendar`, e.Event, e.Recurrence, e.Calendar)
+		}
+	}
+	if _, err := time.LoadLocation(e.TZ); err != nil {
+		return fmt.Errorf(`event "%s" has unknown time zone "%s"`, e.Event, e.TZ)
+	}
+	return nil
+}
+
+// Dates is a function to resolve the dates the event happens on in the given year:
+// a fixed "MM-DD" day gives at most one date, a recurrence rule may give several (e.g. a monthly one),
+// a one-off event or a range gives its start date in its own year only.
+// Days of other calendars than the Gregorian one are converted into Gregorian dates.
+// Events of February 29 are observed in non-leap years according to the leap day policy,
+// events falling on a weekend according to their observance rule (which may move them to another year).
+func Dates(e structs.EventMetadata, year int, leapDay string) []time.Time {
+	dates := nominalDates(e, year, leapDay)
+	for i, tm := range dates {
+		dates[i] = helpers.ObservedWeekday(tm, e.Observe)
+	}
+	return dates
+}
+
+// nominalDates is a function to resolve the dates the event falls on in the given year (see Dates),
+// before its observance rule is applied
+func nominalDates(e structs.EventMetadata, year int, leapDay string) []time.Time {
+	if e.Start != "" {
+		if start, _, err := span(e); err == nil && start.Year() == year {
+			return []time.Time{start}
+		}
+		return nil
+	}
+	var dates []time.Time
+	switch {
+	case e.Recurrence != "":
+		r, err := helpers.ParseRecurrence(e.Recurrence)
+		if err != nil {
+			return nil
+		}
+		dates = r.ResolveIn(e.Calendar, year)
+	case e.Calendar != "" && e.Calendar != helpers.CalendarGregorian:
+		dates = helpers.CalendarDates(e.Calendar, e.Date, year)
+	default:
+		if tm, ok := helpers.ObservedMonthDay(e.Date, year, leapDay); ok {
+			dates = []time.Time{tm}
+		}
+	}
+	return dates
+}
+
+// observe is a function to give the occurrence of the event on the date it is observed on,
+// given the date it nominally falls on
+func observe(e structs.EventMetadata, nominal time.Time) Occurrence {
+	return Occurrence{Event: e, Date: helpers.ObservedWeekday(nominal, e.Observe), Nominal: nominal}
+}
+
+// Age is a method to give the years since the year of the event on the occurrence, counted on the nominal date
+// so that an occurrence observed in the previous year does not count a year less
+func (o Occurrence) Age() int {
+	return o.Nominal.Year() - o.Event.Year
+}
+
+// Upcoming is a function to find the occurrences of events from today up to the given number of days ahead,
+// ranges which are ongoing occur today; occurrences are sorted by the number of days until them and,
+// within a day, by the order of the list.
+// Today is taken in the time zone of every event.
+func Upcoming(list []structs.EventMetadata, conf *ConfigEvents, days int) []Occurrence {
+	var occurrences []Occurrence
+	for _, e := range list {
+		today := conf.TodayFor(e)
+		if e.Start != "" {
+			if o, ok := spanOccurrence(e, today); ok && o.Days >= 0 && o.Days <= days {
+				occurrences = append(occurrences, o)
+			}
+			continue
+		}
+		// Events of the previous or the next year may be observed in this one
+		for y := today.Year() - 1; y <= today.AddDate(0, 0, days).Year()+1; y++ {
+			for _, nominal := range nominalDates(e, y, conf.LeapDay) {
+				o := observe(e, nominal)
+				if o.Days = helpers.DaysBetween(today, o.Date); o.Days >= 0 && o.Days <= days {
+					o.Milestone = conf.Milestone(e, nominal)
+					occurrences = append(occurrences, o)
+				}
+			}
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Days < occurrences[j].Days
+	})
+	return occurrences
+}
+
+// Reported is a function to find the occurrences of events of the type filtered, if any, to report today:
+// today's events and the upcoming ones within the horizon (in days) due to be reminded of (see due),
+// milestones are reminded of the days to remind of milestones in advance at least, even beyond the horizon
+func Reported(list []structs.EventMetadata, conf *ConfigEvents) []Occurrence {
+	var occurrences []Occurrence
+	for _, o := range Upcoming(list, conf, conf.horizon()) {
+		if conf.Filter != "" && o.Event.Type != conf.Filter {
+			continue
+		}
+		if o.Days == 0 || ((o.Days <= conf.Horizon || o.Milestone) && conf.due(o)) {
+			occurrences = append(occurrences, o)
+		}
+	}
+	return occurrences
+}
+
+// due is a method to check the reminder of the upcoming occurrence is due today:
+// reminders in business days are counted in business days and given on business days only
+func (ce *ConfigEvents) due(o Occurrence) bool {
+	if !o.Event.BusinessDays {
+		return ce.remind(o).Due(o.Days)
+	}
+	today := ce.TodayFor(o.Event)
+	return helpers.IsBusinessDay(today) && ce.remind(o).Due(helpers.BusinessDaysBetween(today, o.Date))
+}
+
+// recapDays is the number of days before today the events missed since the last run are looked for at most
+const recapDays = 31
+
+// Missed is a function to find the occurrences of events of the type filtered, if any, after the given day
+// and before today (up to recapDays before it) sorted by date: those not reported if clingo did not run in between.
+// One-off events and ranges occur on the day they start on.
+func Missed(list []structs.EventMetadata, conf *ConfigEvents, since time.Time) []Occurrence {
+	var occurrences []Occurrence
+	for _, e := range list {
+		if conf.Filter != "" && e.Type != conf.Filter {
+			continue
+		}
+		today := conf.TodayFor(e)
+		// Events of the previous year may be observed in this one
+		for y := since.Year() - 1; y <= today.Year(); y++ {
+			for _, nominal := range nominalDates(e, y, conf.LeapDay) {
+				o := observe(e, nominal)
+				o.Days = helpers.DaysBetween(today, o.Date)
+				if o.Days >= 0 || o.Days < -recapDays || helpers.DaysBetween(since, o.Date) <= 0 {
+					continue
+				}
+				o.Milestone = conf.Milestone(e, nominal)
+				if e.End != "" {
+					if _, end, err := span(e); err == nil {
+						o.Day, o.Length = 1, helpers.DaysBetween(o.Date, end)+1
+					}
+				}
+				occurrences = append(occurrences, o)
+			}
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+	return occurrences
+}
+
+// recap is a function to give the section reporting the occurrences missed since the given day, if any
+func recap(occurrences []Occurrence, since time.Time) string {
+	if len(occurrences) == 0 {
+		return ""
+	}
+	output := fmt.Sprintf("Since your last check on %s:\n", since.Format("2006-01-02"))
+	for _, o := range occurrences {
+		output += fmt.Sprintf("  %s: %s%s%s\n", o.Date.Format("2006-01-02"), o.Event.Event, details(o), label(o.Event))
+	}
+	return output
+}
+
+// Run is a function to print the events missed since the last run, if it is known from the state,
+// today's events and reminders about the upcoming ones within the horizon (in days),
+// leaving out those acknowledged or snoozed. Lines of events of a type having a template are rendered with it.
+func Run(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	output := ""
+	if since, ok := conf.State.Since(); ok {
+		output += recap(Missed(list, conf, since), since)
+	}
+	reported := len(output)
+
+	// The date of the occurrence gives its year and age,
+	// so the events of January reminded of in December belong to the next year
+	for _, o := range Reported(list, conf) {
+		e := o.Event
+		if conf.State.Hides(o, conf.TodayFor(e)) {
+			continue
+		}
+		if text, ok := conf.Templates[e.Type]; ok {
+			line, err := render(text, o)
+			if err != nil {
+				return err
+			}
+			output += line + "\n"
+		} else if o.Days == 0 {
+			today := conf.TodayFor(e)
+			output += fmt.Sprintf("Today is %d %s %d: %s%s%s\n",
+				today.Day(), today.Month(), today.Year(), e.Event, details(o), label(e))
+		} else {
+			output += fmt.Sprintf("In %d day(s) will be %s: %s%s%s\n",
+				o.Days, o.Date.Format("2006-01-02"), e.Event, details(o), label(e))
+		}
+	}
+	if len(output) == reported {
+		output += "No events today.\nNo reminders today.\n"
+	}
+
+	_, _ = fmt.Fprint(out, "", output)
+	return nil
+}
+
+// details is a function to give the details of the occurrence reported after the description of the event:
+// the age of a yearly event tagged if it is a milestone, the day of an ongoing range or the length of an upcoming one,
+// nothing for a one-off event or an event of an unknown year
+func details(o Occurrence) string {
+	switch {
+	case o.Length > 0 && o.Days == 0:
+		return fmt.Sprintf(" [ongoing, day %d of %d]", o.Day, o.Length)
+	case o.Length > 0:
+		return fmt.Sprintf(" [%d day(s)]", o.Length)
+	case o.Event.Start != "" || o.Event.Year == 0:
+		return ""
+	case o.Milestone:
+		return fmt.Sprintf(" [%d year(s), milestone]", o.Age())
+	default:
+		return fmt.Sprintf(" [%d year(s)]", o.Age())
+	}
+}
+
+// span is a function to parse the first and the last day of a one-off event or a range,
+// both are the same for a one-off event
+func span(e structs.EventMetadata) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", e.Start)
+	if err != nil {
+		return start, start, fmt.Errorf(`has invalid start "%s", expected YYYY-MM-DD`, e.Start)
+	}
+	if e.End == "" {
+		return start, start, nil
+	}
+	end, err := time.Parse("2006-01-02", e.End)
+	if err != nil {
+		return start, start, fmt.Errorf(`has invalid end "%s", expected YYYY-MM-DD`, e.End)
+	}
+	if end.Before(start) {
+		return start, start, fmt.Errorf(`ends on %s before it starts on %s`, e.End, e.Start)
+	}
+	return start, end, nil
+}
+
+// spanOccurrence is a function to find the occurrence of a one-off event or a range as of today:
+// the start of the event, or today if the range is ongoing. The second returned value is false
+// if the event has no valid start.
+func spanOccurrence(e structs.EventMetadata, today time.Time) (Occurrence, bool) {
+	start, end, err := span(e)
+	if err != nil {
+		return Occurrence{}, false
+	}
+	o := Occurrence{Event: e, Date: start, Days: helpers.DaysBetween(today, start)}
+	if e.End == "" {
+		return o, true
+	}
+	o.Day, o.Length = 1, helpers.DaysBetween(start, end)+1
+	if o.Days < 0 && helpers.DaysBetween(today, end) >= 0 {
+		o.Day = 1 - o.Days
+		o.Date, o.Days = start.AddDate(0, 0, o.Day-1), 0
+	}
+	return o, true
+}
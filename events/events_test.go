@@ -0,0 +1,520 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	birthday := structs.EventMetadata{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"}
+	another := structs.EventMetadata{Date: "03-14", Year: 1990, Remind: structs.Remind{Days: 1}, Type: "birthday", Event: "Another birthday"}
+	christmas := structs.EventMetadata{Date: "12-25", Year: 1, Remind: structs.Remind{Days: 0}, Type: "holiday", Event: "Catholic Christmas Day"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []structs.EventMetadata
+		wantErr bool
+	}{
+		{
+			"single event per day (original format)",
+			`{"12-25": {"year": 1, "remind": 0, "type": "holiday", "event": "Catholic Christmas Day"},
+			  "03-14": {"year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"}}`,
+			[]structs.EventMetadata{birthday, christmas},
+			false,
+		},
+		{
+			"list of events per day",
+			`{"03-14": [{"year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"},
+			            {"year": 1990, "remind": 1, "type": "birthday", "event": "Another birthday"}]}`,
+			[]structs.EventMetadata{birthday, another},
+			false,
+		},
+		{
+			"single event and list of events mixed",
+			`{"12-25": {"year": 1, "remind": 0, "type": "holiday", "event": "Catholic Christmas Day"},
+			  "03-14": [{"year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"}]}`,
+			[]structs.EventMetadata{birthday, christmas},
+			false,
+		},
+		{
+			"list of events",
+			`[{"date": "03-14", "year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"},
+			  {"date": "03-14", "year": 1990, "remind": 1, "type": "birthday", "event": "Another birthday"}]`,
+			[]structs.EventMetadata{birthday, another},
+			false,
+		},
+		{
+			"list of events with a recurrence",
+			`[{"recurrence": "2nd Sunday of May", "year": 1908, "remind": 3, "type": "holiday", "event": "Mother's Day"}]`,
+			[]structs.EventMetadata{{Recurrence: "2nd Sunday of May", Year: 1908, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Mother's Day"}},
+			false,
+		},
+		{
+			"list of reminder days",
+			`[{"date": "04-11", "year": 2012, "remind": [1, 30, 7], "type": "anniversary", "event": "Wedding anniversary"}]`,
+			[]structs.EventMetadata{{Date: "04-11", Year: 2012, Remind: structs.Remind{Offsets: []int{30, 7, 1}}, Type: "anniversary", Event: "Wedding anniversary"}},
+			false,
+		},
+		{"bad reminder", `[{"date": "04-11", "remind": "soon", "event": "Wedding anniversary"}]`, nil, true},
+		{"list of events without date", `[{"year": 2000, "event": "Someone's birthday"}]`, nil, true},
+		{"list of events with date and recurrence", `[{"date": "05-08", "recurrence": "Easter", "event": "Easter"}]`, nil, true},
+		{"list of events with bad recurrence", `[{"recurrence": "every day", "event": "Daily"}]`, nil, true},
+		{"map of events with recurrence", `{"05-08": {"recurrence": "Easter", "event": "Easter"}}`, nil, true},
+		{"bad json", `{"03-14": {"year": 2000,}}`, nil, true},
+		{"empty content", ``, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	today := time.Date(2022, time.March, 12, 23, 12, 5, 3, time.UTC)
+	list := []structs.EventMetadata{
+		{Date: "03-12", Year: 2012, Remind: structs.Remind{Days: 0}, Type: "anniversary", Event: "First anniversary"},
+		{Date: "03-12", Year: 2000, Remind: structs.Remind{Days: 0}, Type: "birthday", Event: "First birthday"},
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Second birthday"},
+		{Date: "03-14", Year: 1990, Remind: structs.Remind{Days: 1}, Type: "birthday", Event: "Third birthday"},
+	}
+
+	tests := []struct {
+		name    string
+		list    []structs.EventMetadata
+		filter  string
+		wantOut string
+	}{
+		{
+			"all events of the same day are reported",
+			list,
+			"",
+			"Today is 12 March 2022: First anniversary [10 year(s)]\n" +
+				"Today is 12 March 2022: First birthday [22 year(s)]\n" +
+				"In 2 day(s) will be 2022-03-14: Second birthday [22 year(s)]\n",
+		},
+		{
+			"events filtered by type",
+			list,
+			"anniversary",
+			"Today is 12 March 2022: First anniversary [10 year(s)]\n",
+		},
+		{
+			"recurring events are resolved",
+			[]structs.EventMetadata{
+				{Recurrence: "2nd Sunday of March", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Second Sunday"},
+				{Recurrence: "Easter-36", Year: 2000, Remind: structs.Remind{Days: 0}, Type: "holiday", Event: "Lent"},
+			},
+			"",
+			"Today is 12 March 2022: Lent [22 year(s)]\n" +
+				"In 1 day(s) will be 2022-03-13: Second Sunday [22 year(s)]\n",
+		},
+		{
+			"reminders far ahead and on exact days",
+			[]structs.EventMetadata{
+				{Date: "04-11", Year: 2012, Remind: structs.Remind{Days: 30}, Type: "anniversary", Event: "Wedding anniversary"},
+				{Date: "03-19", Year: 2000, Remind: structs.Remind{Offsets: []int{30, 7, 1}}, Type: "birthday", Event: "Week ahead"},
+				{Date: "03-20", Year: 2000, Remind: structs.Remind{Offsets: []int{30, 7, 1}}, Type: "birthday", Event: "Not on this day"},
+				{Date: "05-12", Year: 2000, Remind: structs.Remind{Days: 61}, Type: "birthday", Event: "Beyond horizon"},
+			},
+			"",
+			"In 7 day(s) will be 2022-03-19: Week ahead [22 year(s)]\n" +
+				"In 30 day(s) will be 2022-04-11: Wedding anniversary [10 year(s)]\n",
+		},
+		{"no events", nil, "", "No events today.\nNo reminders today.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, tt.list, &ConfigEvents{Filter: tt.filter, Horizon: 30, LeapDay: helpers.LeapDayFeb28, Today: today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+// Verify that events of January reminded of at the end of December are reported
+// with the date and the age of the next year
+func TestRunYearBoundary(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "12-31", Year: 2000, Remind: structs.Remind{Days: 7}, Type: "birthday", Event: "New Year's Eve birthday"},
+		{Date: "01-01", Year: 2000, Remind: structs.Remind{Days: 7}, Type: "birthday", Event: "New Year's Day birthday"},
+		{Date: "01-02", Year: 2010, Remind: structs.Remind{Days: 7}, Type: "anniversary", Event: "Anniversary"},
+		{Recurrence: "1st Monday of January", Year: 2000, Remind: structs.Remind{Days: 7}, Type: "holiday", Event: "First Monday"},
+	}
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		wantOut string
+	}{
+		{
+			"December 28",
+			time.Date(2022, time.December, 28, 9, 0, 0, 0, time.UTC),
+			"In 3 day(s) will be 2022-12-31: New Year's Eve birthday [22 year(s)]\n" +
+				"In 4 day(s) will be 2023-01-01: New Year's Day birthday [23 year(s)]\n" +
+				"In 5 day(s) will be 2023-01-02: Anniversary [13 year(s)]\n" +
+				"In 5 day(s) will be 2023-01-02: First Monday [23 year(s)]\n",
+		},
+		{
+			"December 31",
+			time.Date(2022, time.December, 31, 23, 59, 0, 0, time.UTC),
+			"Today is 31 December 2022: New Year's Eve birthday [22 year(s)]\n" +
+				"In 1 day(s) will be 2023-01-01: New Year's Day birthday [23 year(s)]\n" +
+				"In 2 day(s) will be 2023-01-02: Anniversary [13 year(s)]\n" +
+				"In 2 day(s) will be 2023-01-02: First Monday [23 year(s)]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, list, &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: tt.today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+// Verify that events of February 29 are observed according to the leap day policy in the today check and reminders
+func TestRunLeapDay(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "02-29", Year: 2000, Remind: structs.Remind{Days: 1}, Type: "birthday", Event: "Leap day birthday"},
+	}
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		leapDay string
+		wantOut string
+	}{
+		{
+			"leap year",
+			time.Date(2024, time.February, 28, 9, 0, 0, 0, time.UTC),
+			helpers.LeapDaySkip,
+			"In 1 day(s) will be 2024-02-29: Leap day birthday [24 year(s)]\n",
+		},
+		{
+			"observed on February 28",
+			time.Date(2023, time.February, 28, 9, 0, 0, 0, time.UTC),
+			helpers.LeapDayFeb28,
+			"Today is 28 February 2023: Leap day birthday [23 year(s)]\n",
+		},
+		{
+			"reminded of before February 28",
+			time.Date(2023, time.February, 27, 9, 0, 0, 0, time.UTC),
+			helpers.LeapDayFeb28,
+			"In 1 day(s) will be 2023-02-28: Leap day birthday [23 year(s)]\n",
+		},
+		{
+			"observed on March 1",
+			time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC),
+			helpers.LeapDayMar1,
+			"Today is 1 March 2023: Leap day birthday [23 year(s)]\n",
+		},
+		{
+			"skipped in non-leap years",
+			time.Date(2023, time.February, 28, 9, 0, 0, 0, time.UTC),
+			helpers.LeapDaySkip,
+			"No events today.\nNo reminders today.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, list, &ConfigEvents{Horizon: 10, LeapDay: tt.leapDay, Today: tt.today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+
+	err := Run(&bytes.Buffer{}, list, &ConfigEvents{LeapDay: "feb30"})
+	if err == nil {
+		t.Errorf("Run() error = %v, want unknown leap day policy", err)
+	}
+}
+
+func TestConfigEvents_Resolve(t *testing.T) {
+	now := time.Date(2022, time.March, 12, 23, 12, 5, 3, time.UTC)
+
+	sf, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		date     string
+		tz       string
+		want     time.Time
+		wantDate string
+		wantErr  bool
+	}{
+		{"current time", "", "", now, "2022-03-12", false},
+		{"current time in time zone ahead", "", "Europe/Amsterdam", now, "2022-03-13", false},
+		{"given date", "2021-12-31", "", time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC), "2021-12-31", false},
+		{"given date in time zone", "2021-12-31", "America/Los_Angeles", time.Date(2021, time.December, 31, 0, 0, 0, 0, sf), "2021-12-31", false},
+		{"malformed date", "31-12-2021", "", time.Time{}, "0001-01-01", true},
+		{"unknown time zone", "", "Europe/Atlantis", time.Time{}, "0001-01-01", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &ConfigEvents{Date: tt.date, TZ: tt.tz}
+			err := conf.Resolve(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !conf.Today.Equal(tt.want) {
+				t.Errorf("Resolve() got = %v, want %v", conf.Today, tt.want)
+			}
+			if got := conf.Today.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("Resolve() got date = %v, want %v", got, tt.wantDate)
+			}
+		})
+	}
+}
+
+// Verify that today is taken in the time zone of the event if it has one
+func TestRunTimeZones(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-12", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Amsterdam birthday", TZ: "Europe/Amsterdam"},
+		{Date: "03-12", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "San Francisco birthday", TZ: "America/Los_Angeles"},
+		{Date: "03-12", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Birthday"},
+	}
+	// It is still March 11 in San Francisco
+	now := time.Date(2022, time.March, 12, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    string
+		wantOut string
+	}{
+		{
+			"current time",
+			"",
+			"Today is 12 March 2022: Amsterdam birthday [22 year(s)]\n" +
+				"Today is 12 March 2022: Birthday [22 year(s)]\n" +
+				"In 1 day(s) will be 2022-03-12: San Francisco birthday [22 year(s)]\n",
+		},
+		{
+			"given date is the same in every time zone",
+			"2022-03-12",
+			"Today is 12 March 2022: Amsterdam birthday [22 year(s)]\n" +
+				"Today is 12 March 2022: San Francisco birthday [22 year(s)]\n" +
+				"Today is 12 March 2022: Birthday [22 year(s)]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Date: tt.date, TZ: "UTC"}
+			if err := conf.Resolve(now); err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			out := &bytes.Buffer{}
+			if err := Run(out, list, conf); err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestRunCalendars(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "01-01", Calendar: helpers.CalendarChinese, Year: 1990, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Chinese New Year"},
+		{Date: "09-01", Calendar: helpers.CalendarIslamic, Year: 2000, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Ramadan"},
+		{Recurrence: "Easter", Calendar: helpers.CalendarJulian, Year: 2000, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Orthodox Easter"},
+		{Date: "09-25", Calendar: helpers.CalendarHebrew, Year: 2000, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Hanukkah"},
+	}
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		wantOut string
+	}{
+		{
+			"lunar new year and Ramadan",
+			time.Date(2026, time.February, 16, 9, 0, 0, 0, time.UTC),
+			"In 1 day(s) will be 2026-02-17: Chinese New Year [36 year(s)]\nIn 2 day(s) will be 2026-02-18: Ramadan [26 year(s)]\n",
+		},
+		{
+			"Orthodox Easter",
+			time.Date(2026, time.April, 12, 9, 0, 0, 0, time.UTC),
+			"Today is 12 April 2026: Orthodox Easter [26 year(s)]\n",
+		},
+		{
+			"Hanukkah",
+			time.Date(2025, time.December, 13, 9, 0, 0, 0, time.UTC),
+			"In 2 day(s) will be 2025-12-15: Hanukkah [25 year(s)]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, list, &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: tt.today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+
+	for _, e := range []structs.EventMetadata{
+		{Date: "01-01", Calendar: "mayan", Type: "holiday", Event: "Unknown calendar"},
+		{Recurrence: "2nd Sunday of May", Calendar: helpers.CalendarHebrew, Type: "holiday", Event: "Unsupported rule"},
+		{Date: "13-01", Calendar: helpers.CalendarChinese, Type: "holiday", Event: "No such month"},
+	} {
+		if err := Validate(e); err == nil {
+			t.Errorf("Validate() of %s error = nil, want an error", e.Event)
+		}
+	}
+}
+
+func TestRunRanges(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Start: "2026-03-14", Remind: structs.Remind{Days: 3}, Type: "anniversary", Event: "Graduation"},
+		{Start: "2026-03-20", End: "2026-03-24", Remind: structs.Remind{Days: 7}, Type: "holiday", Event: "Ski trip"},
+	}
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		wantOut string
+	}{
+		{
+			"one-off reminder and upcoming range",
+			time.Date(2026, time.March, 13, 9, 0, 0, 0, time.UTC),
+			"In 1 day(s) will be 2026-03-14: Graduation\nIn 7 day(s) will be 2026-03-20: Ski trip [5 day(s)]\n",
+		},
+		{
+			"one-off today",
+			time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC),
+			"Today is 14 March 2026: Graduation\nIn 6 day(s) will be 2026-03-20: Ski trip [5 day(s)]\n",
+		},
+		{
+			"ongoing range",
+			time.Date(2026, time.March, 22, 9, 0, 0, 0, time.UTC),
+			"Today is 22 March 2026: Ski trip [ongoing, day 3 of 5]\n",
+		},
+		{
+			"past events",
+			time.Date(2026, time.March, 25, 9, 0, 0, 0, time.UTC),
+			"No events today.\nNo reminders today.\n",
+		},
+		{
+			"next year",
+			time.Date(2027, time.March, 13, 9, 0, 0, 0, time.UTC),
+			"No events today.\nNo reminders today.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, list, &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: tt.today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+
+	for _, e := range []structs.EventMetadata{
+		{Start: "2026-3-14", Type: "anniversary", Event: "Invalid start"},
+		{Start: "2026-03-24", End: "2026-03-20", Type: "holiday", Event: "Ends before it starts"},
+		{End: "2026-03-24", Type: "holiday", Event: "End without start"},
+		{Date: "03-14", Start: "2026-03-14", Type: "holiday", Event: "Both a date and a start"},
+		{Start: "2026-03-14", Calendar: helpers.CalendarHebrew, Type: "holiday", Event: "Non-gregorian start"},
+	} {
+		if err := Validate(e); err == nil {
+			t.Errorf("Validate() of %s error = nil, want an error", e.Event)
+		}
+	}
+}
+
+func TestRunObservance(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "07-04", Year: 1776, Observe: helpers.ObserveNearestWeekday, Type: "holiday", Event: "Independence Day"},
+		{Date: "12-26", Year: 1, Observe: helpers.ObserveNextMonday, Type: "holiday", Event: "Boxing Day"},
+		{Date: "01-01", Observe: helpers.ObserveNearestWeekday, Remind: structs.Remind{Days: 1}, Type: "holiday", Event: "New Year's Day"},
+		{Date: "10-26", Year: 2016, Remind: structs.Remind{Offsets: []int{1}}, BusinessDays: true, Type: "anniversary", Event: "Work anniversary"},
+		{Date: "10-27", Year: 2000, Remind: structs.Remind{Days: 3}, BusinessDays: true, Type: "birthday", Event: "Colleague's birthday"},
+		{Date: "12-31", Observe: helpers.ObserveNextMonday, Type: "holiday", Event: "New Year's Eve"},
+	}
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		wantOut string
+	}{
+		{"Saturday observed on Friday", time.Date(2026, time.July, 3, 9, 0, 0, 0, time.UTC), "Today is 3 July 2026: Independence Day [250 year(s)]\n"},
+		{"not on the day itself", time.Date(2026, time.July, 4, 9, 0, 0, 0, time.UTC), "No events today.\nNo reminders today.\n"},
+		{"Saturday observed on Monday", time.Date(2026, time.December, 28, 9, 0, 0, 0, time.UTC), "Today is 28 December 2026: Boxing Day [2025 year(s)]\n"},
+		{"observed in the previous year", time.Date(2027, time.December, 30, 9, 0, 0, 0, time.UTC), "In 1 day(s) will be 2027-12-31: New Year's Day\n"},
+		{"observed in the next year", time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC),
+			"Today is 2 January 2023: New Year's Day\nToday is 2 January 2023: New Year's Eve\n"},
+		{"business day reminders on Friday", time.Date(2026, time.October, 23, 9, 0, 0, 0, time.UTC),
+			"In 3 day(s) will be 2026-10-26: Work anniversary [10 year(s)]\nIn 4 day(s) will be 2026-10-27: Colleague's birthday [26 year(s)]\n"},
+		{"no reminders on weekends", time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC), "No events today.\nNo reminders today.\n"},
+		{"business days beyond the window", time.Date(2026, time.October, 21, 9, 0, 0, 0, time.UTC), "No events today.\nNo reminders today.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(out, list, &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: tt.today})
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+
+	// Ages and milestones are counted on the nominal date, even when observed in the previous year
+	out := &bytes.Buffer{}
+	list = []structs.EventMetadata{{Date: "01-01", Year: 2000, Observe: helpers.ObserveNearestWeekday, Type: "anniversary", Event: "Founding anniversary"}}
+	conf := &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Milestones: []string{"%11"}, Today: time.Date(2021, time.December, 31, 9, 0, 0, 0, time.UTC)}
+	if err := Run(out, list, conf); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	if got, want := out.String(), "Today is 31 December 2021: Founding anniversary [22 year(s), milestone]\n"; got != want {
+		t.Errorf("Run() got = %q, want %q", got, want)
+	}
+
+	for _, e := range []structs.EventMetadata{
+		{Date: "07-04", Observe: "previous-friday", Type: "holiday", Event: "Unknown observance"},
+		{Start: "2026-07-04", Observe: helpers.ObserveNextMonday, Type: "holiday", Event: "Observed one-off event"},
+	} {
+		if err := Validate(e); err == nil {
+			t.Errorf("Validate() of %s error = nil, want an error", e.Event)
+		}
+	}
+}
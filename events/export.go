@@ -0,0 +1,173 @@
+package events
+
+import (
+	"clingo/helpers"
+	"clingo/structs"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsWeekdayCodes is a list of weekday codes used in BYDAY part of RRULE, indexed by time.Weekday
+var icsWeekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// listedYears is the number of years the dates of Easter-based events, moved fixed dates, events observed
+// on weekdays and days of other calendars are listed in RDATE for, since RRULE cannot express them
+const listedYears = 10
+
+// Export is a function to write the events in the given format, only "ics" (iCalendar) is supported
+func Export(out io.Writer, list []structs.EventMetadata, format string, conf *ConfigEvents) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	switch strings.ToLower(format) {
+	case "ics":
+		return WriteICS(out, list, conf)
+	default:
+		return fmt.Errorf(`unsupported export format "%s"`, format)
+	}
+}
+
+// WriteICS is a function to write the events as an iCalendar (RFC 5545) feed:
+// every event becomes a VEVENT recurring yearly (or monthly) since its year, with a VALARM per reminder offset,
+// one-off events and ranges do not recur.
+// Today is used for DTSTAMP and as the first year of events without a year,
+// events of February 29 recur according to the leap day policy.
+func WriteICS(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents) error {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//clingo//events//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+
+	for _, e := range list {
+		if err := writeICSEvent(&b, e, conf.Today.UTC(), conf.LeapDay); err != nil {
+			return err
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	_, err := fmt.Fprint(out, b.String())
+	return err
+}
+
+// writeICSEvent is a function to write a single event as a VEVENT component
+func writeICSEvent(b *strings.Builder, e structs.EventMetadata, stamp time.Time, leapDay string) error {
+	var r helpers.Recurrence
+	if e.Recurrence != "" {
+		var err error
+		if r, err = helpers.ParseRecurrence(e.Recurrence); err != nil {
+			return fmt.Errorf(`event "%s": %s`, e.Event, err)
+		}
+	}
+
+	year := e.Year
+	listed := r.Easter || r.Moved || (e.Calendar != "" && e.Calendar != helpers.CalendarGregorian) ||
+		(e.Observe != "" && e.Observe != helpers.ObserveNone)
+	if year <= 0 || (listed && year < stamp.Year()) {
+		// Events with listed dates start this year
+		year = stamp.Year()
+	}
+	start, ok := firstDate(e, year, leapDay)
+	if !ok {
+		return fmt.Errorf(`event "%s" has no dates`, e.Event)
+	}
+
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, fmt.Sprintf("UID:%x@clingo", sha1.Sum([]byte(mergeKey(e)+"|"+e.Calendar+"|"+e.Type))))
+	writeICSLine(b, "DTSTAMP:"+stamp.Format("20060102T150405Z"))
+	writeICSLine(b, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+
+	switch {
+	case e.Start != "":
+		// One-off events and ranges do not recur, the end of a range is exclusive
+		if _, end, err := span(e); err == nil && e.End != "" {
+			writeICSLine(b, "DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format("20060102"))
+		}
+	case listed:
+		// Easter and days of other calendars move against the Gregorian calendar and fixed dates move on a weekday
+		// in a way RRULE cannot express, so list the dates instead
+		var dates []string
+		for y := year; y < year+listedYears; y++ {
+			for _, tm := range Dates(e, y, leapDay) {
+				if tm.After(start) {
+					dates = append(dates, tm.Format("20060102"))
+				}
+			}
+		}
+		writeICSLine(b, "RDATE;VALUE=DATE:"+strings.Join(dates, ","))
+	case e.Date == "02-29" && leapDay == helpers.LeapDayFeb28:
+		// The last day of February is February 29 in leap years and February 28 otherwise
+		writeICSLine(b, "RRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1")
+	case e.Date == "02-29" && leapDay == helpers.LeapDayMar1:
+		// The 60th day of the year is February 29 in leap years and March 1 otherwise
+		writeICSLine(b, "RRULE:FREQ=YEARLY;BYYEARDAY=60")
+	case e.Recurrence == "":
+		// February 29 in non-leap years is an invalid date and it is ignored by RRULE, as the skip policy wants
+		writeICSLine(b, "RRULE:FREQ=YEARLY")
+	case r.Day > 0:
+		writeICSLine(b, fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYMONTHDAY=%d", r.Month, r.Day))
+	case r.Month == 0:
+		writeICSLine(b, fmt.Sprintf("RRULE:FREQ=MONTHLY;BYDAY=%d%s", r.N, icsWeekdayCodes[r.Weekday]))
+	default:
+		writeICSLine(b, fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", r.Month, r.N, icsWeekdayCodes[r.Weekday]))
+	}
+
+	writeICSLine(b, "SUMMARY:"+escapeICS(e.Event))
+	if e.Type != "" {
+		writeICSLine(b, "CATEGORIES:"+escapeICS(e.Type))
+	}
+	writeICSLine(b, "TRANSP:TRANSPARENT")
+	offsets := e.Remind.Offsets
+	if len(offsets) == 0 && e.Remind.Days > 0 {
+		offsets = []int{e.Remind.Days}
+	}
+	for _, offset := range offsets {
+		writeICSLine(b, "BEGIN:VALARM")
+		writeICSLine(b, "ACTION:DISPLAY")
+		writeICSLine(b, "DESCRIPTION:"+escapeICS(e.Event))
+		writeICSLine(b, fmt.Sprintf("TRIGGER:-P%dD", offset))
+		writeICSLine(b, "END:VALARM")
+	}
+	writeICSLine(b, "END:VEVENT")
+
+	return nil
+}
+
+// firstDate is a function to find the first date of the event in the given year or, if there is none
+// (e.g. "02-29" in a non-leap year), in one of the following years; one-off events and ranges give their start
+func firstDate(e structs.EventMetadata, year int, leapDay string) (time.Time, bool) {
+	if start, _, err := span(e); err == nil {
+		return start, true
+	}
+	for y := year; y < year+8; y++ {
+		if dates := Dates(e, y, leapDay); len(dates) > 0 {
+			return dates[0], true
+		}
+	}
+	return time.Time{}, false
+}
+
+// writeICSLine is a function to write a content line terminated with CRLF,
+// folding it into lines of at most 75 octets without splitting UTF-8 characters
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space of a continuation line counts as well
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// escapeICS is a function to escape TEXT values
+func escapeICS(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
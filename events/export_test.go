@@ -0,0 +1,177 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	stamp := time.Date(2022, time.March, 12, 23, 12, 5, 0, time.UTC)
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday; party, cake"},
+		{Recurrence: "last Friday monthly", Year: 2020, Type: "anniversary", Event: "Retro"},
+	}
+
+	out := &bytes.Buffer{}
+	if err := WriteICS(out, list, &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: stamp}); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+
+	want := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//clingo//events//EN\r\nCALSCALE:GREGORIAN\r\n" +
+		"BEGIN:VEVENT\r\nUID:31f9777a43316d1f6179b33b756d027afe9d2333@clingo\r\nDTSTAMP:20220312T231205Z\r\n" +
+		"DTSTART;VALUE=DATE:20000314\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Someone's birthday\\; party\\, cake\r\n" +
+		"CATEGORIES:birthday\r\nTRANSP:TRANSPARENT\r\nBEGIN:VALARM\r\nACTION:DISPLAY\r\n" +
+		"DESCRIPTION:Someone's birthday\\; party\\, cake\r\nTRIGGER:-P3D\r\nEND:VALARM\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:"
+	if got := out.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("WriteICS() got = %q, want prefix %q", got, want)
+	}
+	if !strings.Contains(out.String(), "DTSTART;VALUE=DATE:20200131\r\nRRULE:FREQ=MONTHLY;BYDAY=-1FR\r\n") {
+		t.Errorf("WriteICS() got = %q, want a monthly RRULE", out.String())
+	}
+
+	// Importing the exported feed gives the same events back
+	got, err := ParseICS(out.Bytes())
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, list) {
+		t.Errorf("ParseICS() got = %v, want %v", got, list)
+	}
+}
+
+func TestWriteICSLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"short line", "SUMMARY:Birthday", "SUMMARY:Birthday\r\n"},
+		{
+			"long line is folded",
+			"SUMMARY:" + strings.Repeat("a", 70),
+			"SUMMARY:" + strings.Repeat("a", 67) + "\r\n " + strings.Repeat("a", 3) + "\r\n",
+		},
+		{
+			"multibyte characters are not split",
+			"SUMMARY:" + strings.Repeat("a", 65) + "ééé",
+			"SUMMARY:" + strings.Repeat("a", 65) + "é\r\n éé\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &strings.Builder{}
+			writeICSLine(b, tt.line)
+			if got := b.String(); got != tt.want {
+				t.Errorf("writeICSLine() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteICSLeapDay(t *testing.T) {
+	stamp := time.Date(2022, time.March, 12, 23, 12, 5, 0, time.UTC)
+	list := []structs.EventMetadata{{Date: "02-29", Year: 2001, Type: "birthday", Event: "Leap day birthday"}}
+
+	tests := []struct {
+		leapDay string
+		want    string
+	}{
+		{helpers.LeapDayFeb28, "DTSTART;VALUE=DATE:20010228\r\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1\r\n"},
+		{helpers.LeapDayMar1, "DTSTART;VALUE=DATE:20010301\r\nRRULE:FREQ=YEARLY;BYYEARDAY=60\r\n"},
+		{helpers.LeapDaySkip, "DTSTART;VALUE=DATE:20040229\r\nRRULE:FREQ=YEARLY\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.leapDay, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := WriteICS(out, list, &ConfigEvents{LeapDay: tt.leapDay, Today: stamp}); err != nil {
+				t.Fatalf("WriteICS() error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("WriteICS() got = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteICSUID(t *testing.T) {
+	stamp := time.Date(2022, time.March, 12, 23, 12, 5, 0, time.UTC)
+	list := []structs.EventMetadata{
+		{Start: "2022-03-14", Type: "holiday", Event: "Offsite"},
+		{Start: "2022-06-14", End: "2022-06-16", Type: "holiday", Event: "Offsite"},
+		{Date: "01-01", Type: "holiday", Event: "New Year"},
+		{Date: "01-01", Type: "holiday", Event: "New Year", Calendar: helpers.CalendarChinese},
+	}
+
+	out := &bytes.Buffer{}
+	if err := WriteICS(out, list, &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: stamp}); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	uids := make(map[string]bool)
+	for _, line := range strings.Split(out.String(), "\r\n") {
+		if strings.HasPrefix(line, "UID:") {
+			uids[line] = true
+		}
+	}
+	if len(uids) != len(list) {
+		t.Errorf("WriteICS() got %d distinct UIDs, want %d: %q", len(uids), len(list), out.String())
+	}
+}
+
+func TestWriteICSRecurrence(t *testing.T) {
+	stamp := time.Date(2022, time.March, 12, 23, 12, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event structs.EventMetadata
+		want  string
+	}{
+		{"Easter+1", structs.EventMetadata{Recurrence: "Easter+1"}, "DTSTART;VALUE=DATE:20220418\r\nRDATE;VALUE=DATE:20230410,20240401,20250421,"},
+		{"December 26", structs.EventMetadata{Recurrence: "December 26"}, "DTSTART;VALUE=DATE:20001226\r\nRRULE:FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=26\r\n"},
+		{
+			"moved fixed date",
+			structs.EventMetadata{Recurrence: "April 27, if Sunday then April 26"},
+			"DTSTART;VALUE=DATE:20220427\r\nRDATE;VALUE=DATE:20230427,20240427,20250426,",
+		},
+		{
+			"Orthodox Easter",
+			structs.EventMetadata{Recurrence: "Easter", Calendar: helpers.CalendarJulian},
+			"DTSTART;VALUE=DATE:20220424\r\nRDATE;VALUE=DATE:20230416,20240505,20250420,",
+		},
+		{
+			"Chinese New Year",
+			structs.EventMetadata{Date: "01-01", Calendar: helpers.CalendarChinese},
+			"DTSTART;VALUE=DATE:20220201\r\nRDATE;VALUE=DATE:20230122,20240210,20250129,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.event
+			e.Year, e.Type, e.Event = 2000, "holiday", "Holiday"
+			list := []structs.EventMetadata{e}
+			out := &bytes.Buffer{}
+			if err := WriteICS(out, list, &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: stamp}); err != nil {
+				t.Fatalf("WriteICS() error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("WriteICS() got = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	err := Export(&bytes.Buffer{}, nil, "xml", &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: time.Now()})
+	if err == nil {
+		t.Errorf("Export() error = %v, want unsupported format", err)
+	}
+	err = Export(&bytes.Buffer{}, nil, "ics", &ConfigEvents{LeapDay: "feb30", Today: time.Now()})
+	if err == nil {
+		t.Errorf("Export() error = %v, want unknown leap day policy", err)
+	}
+}
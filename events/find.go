@@ -0,0 +1,73 @@
+package events
+
+import (
+	"clingo/structs"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// searchYears is the number of years to look ahead for the next occurrence of an event,
+// enough for events of February 29 observed in leap years only
+const searchYears = 8
+
+// Find is a function to find the events whose description or type contains the text (case-insensitive)
+// and give their next occurrences from today sorted by the number of days until them;
+// ranges which are ongoing occur today, past one-off events and ranges are left out
+func Find(list []structs.EventMetadata, conf *ConfigEvents, text string) []Occurrence {
+	var occurrences []Occurrence
+	for _, e := range list {
+		if (conf.Filter != "" && e.Type != conf.Filter) || !Matches(e, text) {
+			continue
+		}
+		if next := Upcoming([]structs.EventMetadata{e}, conf, searchYears*366); len(next) > 0 {
+			occurrences = append(occurrences, next[0])
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Days < occurrences[j].Days
+	})
+	return occurrences
+}
+
+// Matches is a function to check the description or the type of the event contains the text (case-insensitive)
+func Matches(e structs.EventMetadata, text string) bool {
+	text = strings.ToLower(text)
+	return strings.Contains(strings.ToLower(e.Event), text) || strings.Contains(strings.ToLower(e.Type), text)
+}
+
+// RunFind is a function to print the next occurrences of the events matching the text (see Find),
+// only the nearest one if all is false
+func RunFind(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents, text string, all bool) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	occurrences := Find(list, conf, text)
+	if len(occurrences) == 0 {
+		return fmt.Errorf(`no upcoming events match "%s"`, text)
+	}
+	if !all {
+		occurrences = occurrences[:1]
+	}
+	output := ""
+	for _, o := range occurrences {
+		output += fmt.Sprintf("%s %s (%s)%s%s\n",
+			o.Event.Event, countdown(o.Days), o.Date.Format("2006-01-02"), details(o), label(o.Event))
+	}
+
+	_, _ = fmt.Fprint(out, "", output)
+	return nil
+}
+
+// countdown is a function to tell how soon an occurrence is, given the number of days until it
+func countdown(days int) string {
+	switch days {
+	case 0:
+		return "is today"
+	case 1:
+		return "in 1 day"
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"testing"
+	"time"
+)
+
+func TestRunFind(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "12-25", Type: "holiday", Event: "Christmas"},
+		{Date: "03-14", Year: 2000, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "02-29", Year: 2000, Type: "birthday", Event: "Leap day birthday"},
+		{Start: "2026-10-16", End: "2026-10-20", Type: "holiday", Event: "Autumn break"},
+		{Start: "2026-01-01", Type: "anniversary", Event: "Past one-off event"},
+	}
+	today := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		text    string
+		all     bool
+		leapDay string
+		wantOut string
+		wantErr bool
+	}{
+		{"countdown", "christmas", false, helpers.LeapDayFeb28, "Christmas in 68 days (2026-12-25)\n", false},
+		{"nearest match only", "birthday", false, helpers.LeapDayFeb28, "Leap day birthday in 133 days (2027-02-28) [27 year(s)]\n", false},
+		{
+			"all matches by type",
+			"BIRTHDAY",
+			true,
+			helpers.LeapDaySkip,
+			"Someone's birthday in 147 days (2027-03-14) [27 year(s)]\nLeap day birthday in 499 days (2028-02-29) [28 year(s)]\n",
+			false,
+		},
+		{"ongoing range", "break", false, helpers.LeapDayFeb28, "Autumn break is today (2026-10-18) [ongoing, day 3 of 5]\n", false},
+		{"past event", "past", false, helpers.LeapDayFeb28, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := RunFind(out, list, &ConfigEvents{LeapDay: tt.leapDay, Today: today}, tt.text, tt.all)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunFind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("RunFind() got = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package events
+
+import (
+	"bytes"
+	"clingo/structs"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of events files
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+	FormatCSV  = "csv"
+	FormatICS  = "ics"
+)
+
+// extensions is a map of file extensions to the formats of events files they stand for
+var extensions = map[string]string{
+	".json": FormatJSON,
+	".yaml": FormatYAML,
+	".yml":  FormatYAML,
+	".toml": FormatTOML,
+	".csv":  FormatCSV,
+	".ics":  FormatICS,
+}
+
+// Format is a function to detect the format of the events file by its extension (case-insensitive),
+// JSON is expected for unknown extensions
+func Format(filePath string) string {
+	if format, ok := extensions[strings.ToLower(filepath.Ext(filePath))]; ok {
+		return format
+	}
+	return FormatJSON
+}
+
+// knownExtension is a function to check the file has the extension of one of the formats of events files
+func knownExtension(filePath string) bool {
+	_, ok := extensions[strings.ToLower(filepath.Ext(filePath))]
+	return ok
+}
+
+// toJSON is a function to convert the content of an events file in YAML, TOML or CSV format into JSON,
+// so that it is loaded and checked the same way. JSON content is given back as is.
+func toJSON(format string, content []byte) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		var value interface{}
+		if err := yaml.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("malformed YAML: %s", err)
+		}
+		return marshalEvents(plainDates(value))
+	case FormatTOML:
+		var value map[string]interface{}
+		if err := toml.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("malformed TOML: %s", err)
+		}
+		return marshalEvents(value)
+	case FormatCSV:
+		list, err := parseCSV(content)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(list)
+	default:
+		return content, nil
+	}
+}
+
+// plainDates is a function to write back the dates YAML decodes into time.Time, e.g. unquoted "start: 2026-03-14",
+// as "2006-01-02" strings, in every map and list of the decoded value
+func plainDates(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("2006-01-02")
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = plainDates(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = plainDates(item)
+		}
+	}
+	return value
+}
+
+// marshalEvents is a function to write a decoded YAML or TOML document as JSON.
+// A document with the only "events" key holding a list of events stands for the list format,
+// since the top level of a TOML document cannot be a list.
+func marshalEvents(value interface{}) ([]byte, error) {
+	if m, ok := value.(map[string]interface{}); ok && len(m) == 1 {
+		if list, ok := m["events"].([]interface{}); ok {
+			value = list
+		}
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unknown events format: %s", err)
+	}
+	return content, nil
+}
+
+// parseCSV is a function to load events from CSV content: the header row names the columns after the fields
+// of events in JSON (date, recurrence, start, end, year, remind, type, event, tz, calendar, observe, business_days)
+// in any order,
+// every following row is an event. Values are separated with commas or, if the header has no commas, semicolons
+// as spreadsheets of some locales do; the reminder is a number or a list of numbers (e.g. "30,7,1" or "30 7 1").
+func parseCSV(content []byte) ([]structs.EventMetadata, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	header := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		header = content[:i]
+	}
+	if !bytes.ContainsRune(header, ',') && bytes.ContainsRune(header, ';') {
+		r.Comma = ';'
+	}
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("malformed CSV: %s", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var columns []string
+	for _, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		switch name {
+		case "date", "recurrence", "start", "end", "year", "remind", "type", "event", "tz", "calendar", "observe", "business_days":
+			columns = append(columns, name)
+		default:
+			return nil, fmt.Errorf(`unknown CSV column "%s", expected date, recurrence, start, end, year, remind, type, event, tz, calendar, observe or business_days`, name)
+		}
+	}
+
+	list := make([]structs.EventMetadata, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		line := i + 2
+		// Spreadsheets often export rows of separators only, e.g. at the end
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		var e structs.EventMetadata
+		for j, value := range row {
+			value = strings.TrimSpace(value)
+			switch columns[j] {
+			case "date":
+				e.Date = value
+			case "recurrence":
+				e.Recurrence = value
+			case "start":
+				e.Start = value
+			case "end":
+				e.End = value
+			case "year":
+				if value != "" {
+					if e.Year, err = strconv.Atoi(value); err != nil {
+						return nil, fmt.Errorf(`row %d: invalid year "%s"`, line, value)
+					}
+				}
+			case "remind":
+				if value != "" {
+					value = strings.Join(strings.FieldsFunc(value, func(r rune) bool {
+						return r == ',' || r == ';' || r == ' '
+					}), ",")
+					if err = e.Remind.Set(value); err != nil {
+						return nil, fmt.Errorf("row %d: %s", line, err)
+					}
+				}
+			case "type":
+				e.Type = value
+			case "event":
+				e.Event = value
+			case "tz":
+				e.TZ = value
+			case "calendar":
+				e.Calendar = value
+			case "observe":
+				e.Observe = value
+			case "business_days":
+				if value != "" {
+					if e.BusinessDays, err = strconv.ParseBool(value); err != nil {
+						return nil, fmt.Errorf(`row %d: invalid business_days "%s", expected true or false`, line, value)
+					}
+				}
+			}
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
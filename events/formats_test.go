@@ -0,0 +1,135 @@
+package events
+
+import (
+	"clingo/structs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFormats(t *testing.T) {
+	want := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Recurrence: "2nd Sunday of May", Year: 1908, Remind: structs.Remind{Offsets: []int{7, 1}}, Type: "holiday", Event: "Mother's Day"},
+		{Date: "01-01", Calendar: "chinese", Type: "holiday", Event: "Chinese New Year"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"events.json", `[
+  {"date": "03-14", "year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"},
+  {"recurrence": "2nd Sunday of May", "year": 1908, "remind": [1, 7], "type": "holiday", "event": "Mother's Day"},
+  {"date": "01-01", "calendar": "chinese", "type": "holiday", "event": "Chinese New Year"}
+]`},
+		{"events.yaml", `
+- date: "03-14"
+  year: 2000
+  remind: 3
+  type: birthday
+  event: Someone's birthday
+- recurrence: 2nd Sunday of May
+  year: 1908
+  remind: [1, 7]
+  type: holiday
+  event: Mother's Day
+- {date: 01-01, calendar: chinese, type: holiday, event: Chinese New Year}
+`},
+		{"events.toml", `
+[[events]]
+date = "03-14"
+year = 2000
+remind = 3
+type = "birthday"
+event = "Someone's birthday"
+
+[[events]]
+recurrence = "2nd Sunday of May"
+year = 1908
+remind = [1, 7]
+type = "holiday"
+event = "Mother's Day"
+
+[[events]]
+date = "01-01"
+calendar = "chinese"
+type = "holiday"
+event = "Chinese New Year"
+`},
+		{"events.csv", `Event,Date,Recurrence,Year,Remind,Type,Calendar
+Someone's birthday,03-14,,2000,3,birthday,
+Mother's Day,,2nd Sunday of May,1908,"7,1",holiday,
+Chinese New Year,01-01,,,,holiday,chinese
+,,,,,,
+`},
+		{"semicolons.csv", `event;date;recurrence;year;remind;type;calendar
+Someone's birthday;03-14;;2000;3;birthday;
+Mother's Day;;2nd Sunday of May;1908;7 1;holiday;
+Chinese New Year;01-01;;;;holiday;chinese
+`},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			got, err := Load(path)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+			require.Empty(t, LintFile(path, time.Now()))
+		})
+	}
+}
+
+func TestLoadYAMLDates(t *testing.T) {
+	want := []structs.EventMetadata{
+		{Start: "2026-03-14", Type: "holiday", Event: "Offsite"},
+		{Start: "2026-06-14", End: "2026-06-16", Type: "holiday", Event: "Offsite"},
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.yaml")
+	content := `
+- {start: 2026-03-14, type: holiday, event: Offsite}
+- start: 2026-06-14
+  end: 2026-06-16
+  type: holiday
+  event: Offsite
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	got, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Empty(t, LintFile(path, time.Now()))
+}
+
+func TestLoadFormatsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"malformed.yaml", "- date: \"03-14\"\n  date: \"03-15\"\n"},
+		{"malformed.toml", "[[events]]\ndate = 03-14\n"},
+		{"unknown-column.csv", "date,event,colour\n03-14,Birthday,red\n"},
+		{"bad-remind.csv", "date,event,remind,type\n03-14,Birthday,soon,birthday\n"},
+		{"bad-year.csv", "date,event,year,type\n03-14,Birthday,MMXX,birthday\n"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			_, err := Load(path)
+			require.Error(t, err)
+			require.NotEmpty(t, LintFile(path, time.Now()))
+		})
+	}
+
+	path := filepath.Join(dir, "negative.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("\"03-14\": {remind: -1, type: birthday, event: Birthday}\n"), 0644))
+	require.Equal(t, []string{`03-14: event "Birthday" has negative reminder -1`}, LintFile(path, time.Now()))
+}
@@ -0,0 +1,282 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"clingo/structs"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	icsDuration = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+	icsByDay    = regexp.MustCompile(`^([+-]?\d)?(MO|TU|WE|TH|FR|SA|SU)$`)
+
+	icsWeekdays = map[string]time.Weekday{
+		"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+		"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+	}
+	icsOrdinals = map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", -1: "last"}
+)
+
+// icsProperty is a struct to keep a single content line of an iCalendar file
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icsEvent is a struct to keep properties of a VEVENT component relevant to events
+type icsEvent struct {
+	Summary    string
+	Categories string
+	Start      icsProperty
+	RRule      map[string]string
+	Alarms     []time.Duration
+}
+
+// ParseICS is a function to load events from iCalendar content.
+// Only VEVENTs recurring every year (RRULE with FREQ=YEARLY) or every month on a weekday
+// (e.g. RRULE:FREQ=MONTHLY;BYDAY=-1FR) are loaded, one-off events are skipped.
+// DTSTART gives the day and the year, SUMMARY gives the description, the first of CATEGORIES gives the type
+// and VALARM triggers before the start give the days to remind in advance:
+// a single alarm gives a number of days, several alarms give a list of days.
+func ParseICS(content []byte) ([]structs.EventMetadata, error) {
+	var list []structs.EventMetadata
+	var current *icsEvent
+	depth := 0
+
+	for _, line := range unfoldICS(content) {
+		p, err := parseICSLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case p.Name == "BEGIN" && strings.EqualFold(p.Value, "VEVENT"):
+			current = &icsEvent{}
+			depth = 0
+		case current == nil:
+			continue
+		case p.Name == "BEGIN":
+			depth++
+		case p.Name == "END" && strings.EqualFold(p.Value, "VEVENT"):
+			e, ok, err := current.metadata()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				list = append(list, e)
+			}
+			current = nil
+		case p.Name == "END":
+			depth--
+		case p.Name == "TRIGGER" && depth > 0:
+			if d, ok := current.trigger(p); ok {
+				current.Alarms = append(current.Alarms, d)
+			}
+		case depth > 0:
+			continue
+		case p.Name == "SUMMARY":
+			current.Summary = unescapeICS(p.Value)
+		case p.Name == "CATEGORIES":
+			current.Categories = unescapeICS(p.Value)
+		case p.Name == "DTSTART":
+			current.Start = p
+		case p.Name == "RRULE":
+			current.RRule = make(map[string]string)
+			for _, part := range strings.Split(p.Value, ";") {
+				if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+					current.RRule[strings.ToUpper(kv[0])] = strings.ToUpper(kv[1])
+				}
+			}
+		}
+	}
+
+	return list, nil
+}
+
+// metadata is a method to convert the VEVENT into EventMetadata,
+// the second returned value is false if the VEVENT is not a recurring event clingo can handle
+func (ie *icsEvent) metadata() (structs.EventMetadata, bool, error) {
+	var e structs.EventMetadata
+	if ie.Start.Value == "" || ie.RRule == nil {
+		return e, false, nil
+	}
+	start, err := parseICSDate(ie.Start.Value)
+	if err != nil {
+		return e, false, fmt.Errorf(`event "%s": %s`, ie.Summary, err)
+	}
+
+	switch freq := ie.RRule["FREQ"]; {
+	case freq == "YEARLY" && ie.RRule["BYDAY"] != "":
+		month := start.Month()
+		if m, err := strconv.Atoi(ie.RRule["BYMONTH"]); err == nil && m >= 1 && m <= 12 {
+			month = time.Month(m)
+		}
+		rule, ok := byDayRule(ie.RRule["BYDAY"])
+		if !ok {
+			return e, false, nil
+		}
+		e.Recurrence = fmt.Sprintf("%s of %s", rule, month)
+	case freq == "YEARLY":
+		e.Date = start.Format("01-02")
+	case freq == "MONTHLY" && ie.RRule["BYDAY"] != "":
+		rule, ok := byDayRule(ie.RRule["BYDAY"])
+		if !ok {
+			return e, false, nil
+		}
+		e.Recurrence = rule + " monthly"
+	default:
+		return e, false, nil
+	}
+
+	e.Year = start.Year()
+	e.Event = ie.Summary
+	if categories := strings.Split(ie.Categories, ","); categories[0] != "" {
+		e.Type = strings.ToLower(strings.TrimSpace(categories[0]))
+	}
+	// A single alarm keeps reminding every day since then, several alarms remind on their days only.
+	// Partial days count as whole ones, e.g. the usual alarm of all-day events on the day before at 09:00 (-PT15H).
+	var offsets []int
+	for _, d := range ie.Alarms {
+		days := int(math.Ceil(d.Hours() / 24))
+		if days > 0 && !containsInt(offsets, days) {
+			offsets = append(offsets, days)
+		}
+	}
+	if len(offsets) == 1 {
+		e.Remind.Days = offsets[0]
+	} else if len(offsets) > 1 {
+		sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+		e.Remind.Offsets = offsets
+	}
+
+	return e, true, nil
+}
+
+// trigger is a method to calculate how long before the start of the VEVENT the VALARM is triggered
+func (ie *icsEvent) trigger(p icsProperty) (time.Duration, bool) {
+	if strings.EqualFold(p.Params["VALUE"], "DATE-TIME") {
+		at, err1 := parseICSDate(p.Value)
+		start, err2 := parseICSDate(ie.Start.Value)
+		if err1 != nil || err2 != nil || at.After(start) {
+			return 0, false
+		}
+		return start.Sub(at), true
+	}
+	if strings.EqualFold(p.Params["RELATED"], "END") {
+		return 0, false
+	}
+	d, err := parseICSDuration(p.Value)
+	if err != nil || d > 0 {
+		return 0, false
+	}
+	return -d, true
+}
+
+// byDayRule is a function to convert a single BYDAY value of RRULE (e.g. "2SU", "-1FR") into a recurrence rule prefix
+func byDayRule(byDay string) (string, bool) {
+	m := icsByDay.FindStringSubmatch(byDay)
+	if m == nil || m[1] == "" {
+		return "", false
+	}
+	n, _ := strconv.Atoi(m[1])
+	ordinal, ok := icsOrdinals[n]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s", ordinal, icsWeekdays[m[2]]), true
+}
+
+// unfoldICS is a function to split iCalendar content into lines, joining lines folded with leading whitespace
+func unfoldICS(content []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICSLine is a function to parse a content line like "DTSTART;VALUE=DATE:20000314"
+func parseICSLine(line string) (icsProperty, error) {
+	p := icsProperty{Params: make(map[string]string)}
+	colon := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf(`malformed iCalendar line "%s"`, line)
+	}
+	p.Value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	p.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if kv := strings.SplitN(param, "=", 2); len(kv) == 2 {
+			p.Params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return p, nil
+}
+
+// parseICSDate is a function to parse DATE ("20000314") and DATE-TIME ("20000314T090000Z") values
+func parseICSDate(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if tm, err := time.Parse(layout, value); err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(`malformed iCalendar date "%s"`, value)
+}
+
+// parseICSDuration is a function to parse DURATION values like "-P3D", "-PT24H" or "-P1W"
+func parseICSDuration(value string) (time.Duration, error) {
+	m := icsDuration.FindStringSubmatch(strings.ToUpper(value))
+	if m == nil {
+		return 0, fmt.Errorf(`malformed iCalendar duration "%s"`, value)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+2] != "" {
+			n, _ := strconv.Atoi(m[i+2])
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// unescapeICS is a function to unescape TEXT values
+func unescapeICS(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// containsInt is a function to check if the list contains the number
+func containsInt(list []int, n int) bool {
+	for _, item := range list {
+		if item == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"clingo/structs"
+	"reflect"
+	"testing"
+)
+
+func TestParseICS(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []structs.EventMetadata
+		wantErr bool
+	}{
+		{
+			"yearly event with alarm and category",
+			"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:1\r\nSUMMARY:Someone's birthday\r\n" +
+				"DTSTART;VALUE=DATE:20000314\r\nRRULE:FREQ=YEARLY\r\nCATEGORIES:Birthday,Family\r\n" +
+				"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-P3D\r\nEND:VALARM\r\n" +
+				"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			[]structs.EventMetadata{{Date: "03-14", Year: 2000, Remind: structs.Remind{Offsets: []int{3, 1}}, Type: "birthday", Event: "Someone's birthday"}},
+			false,
+		},
+		{
+			"folded and escaped lines, alarm in hours and date-time start",
+			"BEGIN:VEVENT\nSUMMARY:Wedding\\, anniver\n sary\nDTSTART:20100105T120000Z\nRRULE:FREQ=YEARLY;INTERVAL=1\n" +
+				"BEGIN:VALARM\nTRIGGER;RELATED=START:-PT48H\nEND:VALARM\nEND:VEVENT\n",
+			[]structs.EventMetadata{{Date: "01-05", Year: 2010, Remind: structs.Remind{Days: 2}, Event: "Wedding, anniversary"}},
+			false,
+		},
+		{
+			"yearly and monthly events on a weekday",
+			"BEGIN:VEVENT\nSUMMARY:Mother's Day\nDTSTART;VALUE=DATE:19080510\nRRULE:FREQ=YEARLY;BYMONTH=5;BYDAY=2SU\n" +
+				"CATEGORIES:HOLIDAY\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:Retro\nDTSTART;VALUE=DATE:20200131\nRRULE:FREQ=MONTHLY;BYDAY=-1FR\nEND:VEVENT\n",
+			[]structs.EventMetadata{
+				{Recurrence: "2nd Sunday of May", Year: 1908, Type: "holiday", Event: "Mother's Day"},
+				{Recurrence: "last Friday monthly", Year: 2020, Event: "Retro"},
+			},
+			false,
+		},
+		{
+			"several alarms give reminder days",
+			"BEGIN:VEVENT\nSUMMARY:Wedding\nDTSTART;VALUE=DATE:20120411\nRRULE:FREQ=YEARLY\n" +
+				"BEGIN:VALARM\nTRIGGER:-P1D\nEND:VALARM\nBEGIN:VALARM\nTRIGGER:-P30D\nEND:VALARM\n" +
+				"BEGIN:VALARM\nTRIGGER:-P1W\nEND:VALARM\nEND:VEVENT\n",
+			[]structs.EventMetadata{{Date: "04-11", Year: 2012, Remind: structs.Remind{Offsets: []int{30, 7, 1}}, Event: "Wedding"}},
+			false,
+		},
+		{
+			"alarms of partial days on the day before",
+			"BEGIN:VEVENT\nSUMMARY:Wedding\nDTSTART;VALUE=DATE:20120411\nRRULE:FREQ=YEARLY\n" +
+				"BEGIN:VALARM\nTRIGGER:-PT15H\nEND:VALARM\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:Retro\nDTSTART;VALUE=DATE:20200131\nRRULE:FREQ=MONTHLY;BYDAY=-1FR\n" +
+				"BEGIN:VALARM\nTRIGGER:-P0DT15H0M0S\nEND:VALARM\nEND:VEVENT\n",
+			[]structs.EventMetadata{
+				{Date: "04-11", Year: 2012, Remind: structs.Remind{Days: 1}, Event: "Wedding"},
+				{Recurrence: "last Friday monthly", Year: 2020, Remind: structs.Remind{Days: 1}, Event: "Retro"},
+			},
+			false,
+		},
+		{
+			"one-off and daily events are skipped",
+			"BEGIN:VEVENT\nSUMMARY:Meeting\nDTSTART:20220314T090000Z\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:Standup\nDTSTART:20220314T090000Z\nRRULE:FREQ=DAILY\nEND:VEVENT\n",
+			nil,
+			false,
+		},
+		{"malformed line", "BEGIN:VEVENT\nSUMMARY\nEND:VEVENT\n", nil, true},
+		{"malformed date", "BEGIN:VEVENT\nDTSTART:2022-03-14\nRRULE:FREQ=YEARLY\nEND:VEVENT\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseICS([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseICS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseICS() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
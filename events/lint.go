@@ -0,0 +1,237 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Types is a list of event types clingo knows about
+var Types = []string{"anniversary", "birthday", "holiday"}
+
+// Problems is a function to check a single event, it returns every problem found:
+// a missing or impossible day, an invalid recurrence rule, a missing description,
+// negative years and reminders, an unknown type.
+func Problems(e structs.EventMetadata) []error {
+	var problems []error
+	if err := checkSchedule(e); err != nil {
+		problems = append(problems, err)
+	}
+	if e.Date != "" {
+		if err := helpers.CheckMonthDay(e.Calendar, e.Date); err != nil {
+			problems = append(problems, fmt.Errorf(`event "%s" has %s`, e.Event, err))
+		}
+	}
+	if strings.TrimSpace(e.Event) == "" {
+		problems = append(problems, fmt.Errorf("event has no description"))
+	}
+	if e.Year < 0 {
+		problems = append(problems, fmt.Errorf(`event "%s" has negative year %d`, e.Event, e.Year))
+	}
+	if e.Remind.Days < 0 {
+		problems = append(problems, fmt.Errorf(`event "%s" has negative reminder %d`, e.Event, e.Remind.Days))
+	}
+	for _, offset := range e.Remind.Offsets {
+		if offset < 0 {
+			problems = append(problems, fmt.Errorf(`event "%s" has negative reminder %d`, e.Event, offset))
+		}
+	}
+	if !knownType(e.Type) {
+		problems = append(problems, fmt.Errorf(`event "%s" has unknown type "%s", expected one of %s`,
+			e.Event, e.Type, strings.Join(Types, ", ")))
+	}
+	return problems
+}
+
+// knownType is a function to check if the event type is one of Types
+func knownType(eventType string) bool {
+	for _, t := range Types {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// LintFile is a function to check the events file, it returns the list of problems found (see Lint).
+// YAML, TOML and CSV files are checked once converted into JSON, iCalendar files are only checked to be readable.
+func LintFile(filePath string, today time.Time) []string {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	format := Format(filePath)
+	if format == FormatICS {
+		if _, err = ParseICS(content); err != nil {
+			return []string{err.Error()}
+		}
+		return nil
+	}
+	if content, err = toJSON(format, content); err != nil {
+		return []string{err.Error()}
+	}
+	return Lint(content, today)
+}
+
+// Lint is a function to check the content of a JSON events file, it returns the list of problems found:
+// malformed JSON, duplicate keys, unknown fields, problems of events (see Problems),
+// years after the current one and events listed more than once on the same day.
+func Lint(content []byte, today time.Time) []string {
+	var problems []string
+	d := json.NewDecoder(bytes.NewReader(content))
+	err := walkJSON(d, "", &problems)
+	if err == nil {
+		if _, err = d.Token(); err == io.EOF {
+			err = nil
+		} else if err == nil {
+			err = errors.New("unexpected content after the events")
+		}
+	}
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line := bytes.Count(content[:syntaxErr.Offset], []byte("\n")) + 1
+			return append(problems, fmt.Sprintf("malformed JSON at line %d: %s", line, err))
+		}
+		return append(problems, fmt.Sprintf("malformed JSON: %s", err))
+	}
+
+	list, err := decode(content)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	problems = append(problems, unknownFields(content)...)
+	seen := make(map[string]bool)
+	for _, e := range list {
+		day := when(e)
+		for _, p := range Problems(e) {
+			problems = append(problems, fmt.Sprintf("%s: %s", day, p))
+		}
+		if e.Year > today.Year() {
+			problems = append(problems, fmt.Sprintf(`%s: event "%s" has year %d in the future`, day, e.Event, e.Year))
+		}
+		key := mergeKey(e)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf(`%s: event "%s" is listed more than once`, day, e.Event))
+		}
+		seen[key] = true
+	}
+
+	return problems
+}
+
+// walkJSON is a function to read a single JSON value token by token, reporting keys repeated in an object
+// since json.Unmarshal silently keeps the last of them
+func walkJSON(d *json.Decoder, path string, problems *[]string) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		keys := make(map[string]bool)
+		for d.More() {
+			if tok, err = d.Token(); err != nil {
+				return err
+			}
+			key := tok.(string)
+			if keys[key] {
+				problem := fmt.Sprintf(`duplicate key "%s"`, key)
+				if path != "" {
+					problem += " in " + path
+				}
+				*problems = append(*problems, problem)
+			}
+			keys[key] = true
+			if err = walkJSON(d, strings.TrimPrefix(path+"/"+key, "/"), problems); err != nil {
+				return err
+			}
+		}
+		_, err = d.Token()
+	case json.Delim('['):
+		for i := 0; d.More(); i++ {
+			if err = walkJSON(d, fmt.Sprintf("%s[%d]", path, i), problems); err != nil {
+				return err
+			}
+		}
+		_, err = d.Token()
+	}
+	return err
+}
+
+// unknownFields is a function to report fields of events which are not fields of EventMetadata,
+// they are most likely misspelled
+func unknownFields(content []byte) []string {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(structs.EventMetadata{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil
+	}
+	var objects []map[string]interface{}
+	var days []string
+	collect := func(day string, v interface{}) {
+		items, ok := v.([]interface{})
+		if !ok {
+			items = []interface{}{v}
+		}
+		for _, item := range items {
+			if object, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, object)
+				days = append(days, day)
+			}
+		}
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		collect("", v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collect(key, v[key])
+		}
+	}
+
+	var problems []string
+	for i, object := range objects {
+		day := days[i]
+		for _, field := range []string{"date", "recurrence", "start"} {
+			if s, ok := object[field].(string); ok && day == "" {
+				day = s
+			}
+		}
+		if s, ok := object["end"].(string); ok && days[i] == "" {
+			day += ".." + s
+		}
+		fields := make([]string, 0, len(object))
+		for field := range object {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if !known[field] {
+				problems = append(problems, fmt.Sprintf(`%s: event "%v" has unknown field "%s"`, day, object["event"], field))
+			}
+		}
+	}
+	return problems
+}
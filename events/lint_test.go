@@ -0,0 +1,239 @@
+package events
+
+import (
+	"clingo/structs"
+	"encoding/json"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	today := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "clean list",
+			content: `[{"date": "02-29", "year": 2000, "remind": [7, 1], "type": "birthday", "event": "Leap birthday"}]`,
+		},
+		{
+			name:    "clean days",
+			content: `{"03-14": [{"year": 2000, "remind": 3, "type": "birthday", "event": "Birthday"}]}`,
+		},
+		{
+			name:    "malformed JSON",
+			content: "[\n  {\"date\": \"03-14\",}\n]",
+			want:    []string{"malformed JSON at line 2"},
+		},
+		{
+			name:    "duplicate keys",
+			content: `{"03-14": {"year": 2000, "year": 2001, "type": "birthday", "event": "A"}, "03-14": {"type": "birthday", "event": "B"}}`,
+			want:    []string{`duplicate key "year" in 03-14`, `duplicate key "03-14"`},
+		},
+		{
+			name:    "impossible date",
+			content: `{"02-31": {"year": 2000, "type": "birthday", "event": "Birthday"}}`,
+			want:    []string{`02-31: event "Birthday" has invalid date "02-31", expected "MM-DD"`},
+		},
+		{
+			name:    "future year",
+			content: `[{"date": "03-14", "year": 2100, "type": "birthday", "event": "Birthday"}]`,
+			want:    []string{`03-14: event "Birthday" has year 2100 in the future`},
+		},
+		{
+			name:    "negative remind",
+			content: `[{"date": "03-14", "remind": [7, -1], "type": "birthday", "event": "Birthday"}]`,
+			want:    []string{`03-14: event "Birthday" has negative reminder -1`},
+		},
+		{
+			name:    "unknown type",
+			content: `[{"date": "03-14", "type": "party", "event": "Party"}]`,
+			want:    []string{`03-14: event "Party" has unknown type "party", expected one of anniversary, birthday, holiday`},
+		},
+		{
+			name:    "unknown field",
+			content: `[{"date": "03-14", "type": "birthday", "event": "Birthday", "colour": "red"}]`,
+			want:    []string{`03-14: event "Birthday" has unknown field "colour"`},
+		},
+		{
+			name:    "listed twice",
+			content: `[{"date": "03-14", "type": "birthday", "event": "Birthday"}, {"date": "03-14", "type": "birthday", "event": "Birthday"}]`,
+			want:    []string{`03-14: event "Birthday" is listed more than once`},
+		},
+		{
+			name:    "same description on other days",
+			content: `[{"start": "2026-03-14", "type": "holiday", "event": "Offsite"}, {"start": "2026-06-14", "end": "2026-06-16", "type": "holiday", "event": "Offsite"}]`,
+			want:    nil,
+		},
+		{
+			name:    "range listed twice",
+			content: `[{"start": "2026-06-14", "end": "2026-06-16", "type": "holiday", "event": "Offsite"}, {"start": "2026-06-14", "end": "2026-06-16", "type": "holiday", "event": "Offsite"}]`,
+			want:    []string{`2026-06-14..2026-06-16: event "Offsite" is listed more than once`},
+		},
+		{
+			name:    "wrong schema",
+			content: `"03-14"`,
+			want:    []string{"unknown events format: expected a list of events or events grouped by day"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lint([]byte(tt.content), today)
+			require.Len(t, got, len(tt.want), "problems: %q", got)
+			for i := range tt.want {
+				// Messages of the JSON decoder vary across Go versions, so only their beginning is compared
+				require.True(t, strings.HasPrefix(got[i], tt.want[i]), "got %q, want %q", got[i], tt.want[i])
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	content, err := os.ReadFile("schema.json")
+	require.NoError(t, err)
+
+	var schema struct {
+		Defs struct {
+			Event struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"event"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(content, &schema))
+
+	var got, want []string
+	for name := range schema.Defs.Event.Properties {
+		got = append(got, name)
+	}
+	eventType := reflect.TypeOf(structs.EventMetadata{})
+	for i := 0; i < eventType.NumField(); i++ {
+		if name := strings.Split(eventType.Field(i).Tag.Get("json"), ",")[0]; name != "-" {
+			want = append(want, name)
+		}
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	require.Equal(t, want, got, "the schema should describe every field of an event")
+
+	var root map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &root))
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{"list", `[{"date": "03-14", "type": "birthday", "event": "Birthday"}, {"start": "2026-06-14", "end": "2026-06-16", "type": "holiday", "event": "Offsite"}]`, true},
+		{"grouped by day", `{"03-14": {"remind": [7, 1], "type": "birthday", "event": "Birthday"}, "03-15": [{"year": 1990, "type": "birthday", "event": "Birthday"}]}`, true},
+		{"listed without a day", `[{"type": "birthday", "event": "Birthday"}]`, false},
+		{"listed with a date and a start", `[{"date": "03-14", "start": "2026-03-14", "type": "birthday", "event": "Birthday"}]`, false},
+		{"grouped with a recurrence rule", `{"03-14": {"recurrence": "2nd Sunday of May", "type": "holiday", "event": "Mother's Day"}}`, false},
+		{"end without a start", `[{"date": "03-14", "end": "2026-03-16", "type": "holiday", "event": "Offsite"}]`, false},
+		{"unknown field", `[{"date": "03-14", "type": "birthday", "event": "Birthday", "colour": "red"}]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.content), &value))
+			require.Equal(t, tt.valid, validate(root, root, value), "the schema should tell valid events files")
+			require.Equal(t, tt.valid, len(Lint([]byte(tt.content), time.Now())) == 0, "the schema should agree with Lint")
+		})
+	}
+}
+
+// validate is a function to check the decoded JSON value against the JSON schema, given its root for references,
+// only the keywords schema.json uses are supported
+func validate(root map[string]interface{}, schema map[string]interface{}, value interface{}) bool {
+	object, isObject := value.(map[string]interface{})
+	for keyword, arg := range schema {
+		ok := true
+		switch keyword {
+		case "$ref":
+			ok = validate(root, root["$defs"].(map[string]interface{})[strings.TrimPrefix(arg.(string), "#/$defs/")].(map[string]interface{}), value)
+		case "type":
+			switch v := value.(type) {
+			case []interface{}:
+				ok = arg == "array"
+			case map[string]interface{}:
+				ok = arg == "object"
+			case string:
+				ok = arg == "string"
+			case bool:
+				ok = arg == "boolean"
+			case float64:
+				ok = arg == "integer" && v == float64(int(v))
+			default:
+				ok = false
+			}
+		case "enum":
+			ok = false
+			for _, allowed := range arg.([]interface{}) {
+				ok = ok || allowed == value
+			}
+		case "pattern":
+			if s, isString := value.(string); isString {
+				ok = regexp.MustCompile(arg.(string)).MatchString(s)
+			}
+		case "minLength":
+			if s, isString := value.(string); isString {
+				ok = len(s) >= int(arg.(float64))
+			}
+		case "minimum":
+			if n, isNumber := value.(float64); isNumber {
+				ok = n >= arg.(float64)
+			}
+		case "items":
+			items, _ := value.([]interface{})
+			for _, item := range items {
+				ok = ok && validate(root, arg.(map[string]interface{}), item)
+			}
+		case "required":
+			for _, name := range arg.([]interface{}) {
+				_, found := object[name.(string)]
+				ok = ok && (!isObject || found)
+			}
+		case "dependentRequired":
+			for name, names := range arg.(map[string]interface{}) {
+				if _, found := object[name]; found {
+					ok = ok && validate(root, map[string]interface{}{"required": names}, value)
+				}
+			}
+		case "propertyNames":
+			for name := range object {
+				ok = ok && validate(root, arg.(map[string]interface{}), name)
+			}
+		case "properties", "additionalProperties":
+			properties, _ := schema["properties"].(map[string]interface{})
+			for name, v := range object {
+				if property, found := properties[name]; found {
+					ok = ok && validate(root, property.(map[string]interface{}), v)
+				} else if additional, found := schema["additionalProperties"]; found {
+					subschema, isSchema := additional.(map[string]interface{})
+					ok = ok && isSchema && validate(root, subschema, v)
+				}
+			}
+		case "oneOf", "anyOf":
+			matches := 0
+			for _, subschema := range arg.([]interface{}) {
+				if validate(root, subschema.(map[string]interface{}), value) {
+					matches++
+				}
+			}
+			ok = matches == 1 || (keyword == "anyOf" && matches > 1)
+		case "not":
+			ok = !validate(root, arg.(map[string]interface{}), value)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"clingo/structs"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseMilestone is a function to parse a milestone: a number of years (e.g. "25")
+// or every multiple of a number of years (e.g. "%10"); the second returned value is true for the latter
+func parseMilestone(value string) (int, bool, error) {
+	every := strings.HasPrefix(value, "%")
+	n, err := strconv.Atoi(strings.TrimPrefix(value, "%"))
+	if err != nil || n < 1 {
+		return 0, false, fmt.Errorf(`invalid milestone "%s", expected a number of years (e.g. 25) or "%%" and a number for its multiples (e.g. %%10)`, value)
+	}
+	return n, every, nil
+}
+
+// checkMilestones is a function to verify the milestones are valid
+func checkMilestones(milestones []string) error {
+	for _, value := range milestones {
+		if _, _, err := parseMilestone(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Milestone is a method to check the occurrence of the event on the date is one of the milestones:
+// a yearly event of a known year reaching a milestone number of years on that date
+func (ce *ConfigEvents) Milestone(e structs.EventMetadata, date time.Time) bool {
+	if e.Year <= 0 || e.Start != "" {
+		return false
+	}
+	years := date.Year() - e.Year
+	for _, value := range ce.Milestones {
+		n, every, err := parseMilestone(value)
+		if err == nil && (years == n || (every && years > 0 && years%n == 0)) {
+			return true
+		}
+	}
+	return false
+}
+
+// remind is a method to give the reminder of the occurrence, extended to the days to remind of milestones in advance
+// for milestones
+func (ce *ConfigEvents) remind(o Occurrence) structs.Remind {
+	if o.Milestone {
+		return o.Event.Remind.Extend(ce.MilestoneRemind)
+	}
+	return o.Event.Remind
+}
+
+// horizon is a method to give the number of days to look for reminders ahead,
+// the days to remind of milestones in advance if they go beyond the horizon
+func (ce *ConfigEvents) horizon() int {
+	if len(ce.Milestones) > 0 && ce.MilestoneRemind > ce.Horizon {
+		return ce.MilestoneRemind
+	}
+	return ce.Horizon
+}
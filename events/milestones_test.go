@@ -0,0 +1,69 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"testing"
+	"time"
+)
+
+func TestRunMilestones(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "11-10", Year: 1976, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "10-26", Year: 2016, Remind: structs.Remind{Offsets: []int{14, 1}}, Type: "anniversary", Event: "Work anniversary"},
+		{Date: "10-20", Year: 2019, Remind: structs.Remind{Days: 3}, Type: "anniversary", Event: "Seventh anniversary"},
+		{Date: "10-30", Year: 2025, Type: "anniversary", Event: "First anniversary"},
+		{Date: "10-30", Type: "birthday", Event: "Birthday of an unknown year"},
+	}
+	today := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		milestones []string
+		remind     int
+		wantOut    string
+		wantErr    bool
+	}{
+		{
+			"no milestones",
+			nil,
+			30,
+			"In 2 day(s) will be 2026-10-20: Seventh anniversary [7 year(s)]\n",
+			false,
+		},
+		{
+			"milestones beyond the horizon",
+			[]string{"1", "5", "25", "%10"},
+			30,
+			"In 2 day(s) will be 2026-10-20: Seventh anniversary [7 year(s)]\n" +
+				"In 12 day(s) will be 2026-10-30: First anniversary [1 year(s), milestone]\n" +
+				"In 23 day(s) will be 2026-11-10: Someone's birthday [50 year(s), milestone]\n",
+			false,
+		},
+		{
+			"milestone reminder as one more day of a list",
+			[]string{"%10"},
+			8,
+			"In 2 day(s) will be 2026-10-20: Seventh anniversary [7 year(s)]\n" +
+				"In 8 day(s) will be 2026-10-26: Work anniversary [10 year(s), milestone]\n",
+			false,
+		},
+		{"invalid milestone", []string{"10th"}, 30, "", true},
+		{"negative milestone reminder", []string{"%10"}, -1, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			conf := &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: today,
+				Milestones: tt.milestones, MilestoneRemind: tt.remind}
+			err := Run(out, list, conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.String(); got != tt.wantOut {
+				t.Errorf("Run() got = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"clingo/structs"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Sources is a method to list the events files to load in the order of precedence, the lowest first:
+// the paths are taken in the order given, a directory (e.g. "events.d") stands for the events files it contains
+// in any of the supported formats (see Load), taken in lexical order of their names. Hidden files are skipped.
+func (ce *ConfigEvents) Sources() ([]string, error) {
+	var sources []string
+	for _, path := range ce.Paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			// A missing file is reported when it is loaded
+			sources = append(sources, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && !strings.HasPrefix(name, ".") && knownExtension(name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sources = append(sources, filepath.Join(path, name))
+		}
+	}
+	return sources, nil
+}
+
+// File is a method to give the single events file to change, events merged from several sources cannot be changed
+func (ce *ConfigEvents) File() (string, error) {
+	if len(ce.Paths) != 1 {
+		return "", fmt.Errorf("events can only be changed in a single file, got %d events sources", len(ce.Paths))
+	}
+	if info, err := os.Stat(ce.Paths[0]); err == nil && info.IsDir() {
+		return "", fmt.Errorf(`events can only be changed in a single file, "%s" is a directory`, ce.Paths[0])
+	}
+	return ce.Paths[0], nil
+}
+
+// LoadAll is a function to load and merge the events of the files given in the order of precedence (see Merge).
+// Events are labelled with the file they come from if there is more than one file.
+func LoadAll(sources []string) ([]structs.EventMetadata, error) {
+	var list []structs.EventMetadata
+	for _, source := range sources {
+		more, err := Load(source)
+		if err != nil {
+			return nil, fmt.Errorf(`events file "%s": %s`, source, err)
+		}
+		if len(sources) > 1 {
+			for i := range more {
+				more[i].Source = source
+			}
+		}
+		list = Merge(list, more)
+	}
+	return list, nil
+}
+
+// Merge is a function to add the events of a source of higher precedence to the list:
+// an event on the same day (date, recurrence rule or start and end) with the same description replaces the one of the list
+// keeping its position, other events are appended.
+func Merge(list []structs.EventMetadata, more []structs.EventMetadata) []structs.EventMetadata {
+	positions := make(map[string]int)
+	for i, e := range list {
+		positions[mergeKey(e)] = i
+	}
+	for _, e := range more {
+		if i, ok := positions[mergeKey(e)]; ok {
+			list[i] = e
+			continue
+		}
+		positions[mergeKey(e)] = len(list)
+		list = append(list, e)
+	}
+	return list
+}
+
+// mergeKey is a function to give the key events are considered the same by when merged, linted and imported
+func mergeKey(e structs.EventMetadata) string {
+	return e.Date + "|" + e.Recurrence + "|" + e.Start + "|" + e.End + "|" + e.Event
+}
+
+// label is a function to give the suffix of a reported event naming the file it comes from, if it is labelled
+func label(e structs.EventMetadata) string {
+	if e.Source == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", e.Source)
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAll(t *testing.T) {
+	dir := t.TempDir()
+	personal := filepath.Join(dir, "events.json")
+	eventsDir := filepath.Join(dir, "events.d")
+	require.NoError(t, os.Mkdir(eventsDir, 0755))
+	files := map[string]string{
+		personal: `[
+  {"date": "03-14", "year": 2000, "remind": 3, "type": "birthday", "event": "Someone's birthday"},
+  {"date": "12-25", "year": 1, "remind": 0, "type": "holiday", "event": "Christmas Day"}
+]`,
+		filepath.Join(eventsDir, "10-team.json"): `{"03-15": [{"year": 1990, "remind": 1, "type": "birthday", "event": "Colleague's birthday"}]}`,
+		filepath.Join(eventsDir, "20-holidays.json"): `[
+  {"date": "12-25", "year": 1, "remind": 7, "type": "holiday", "event": "Christmas Day"}
+]`,
+		filepath.Join(eventsDir, ".hidden.json"): `not even JSON`,
+		filepath.Join(eventsDir, "README.md"):    `not an events file`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+	}
+
+	conf := ConfigEvents{Paths: []string{personal, eventsDir}, Horizon: 30, LeapDay: helpers.LeapDayFeb28,
+		Today: time.Date(2022, time.March, 14, 0, 0, 0, 0, time.UTC)}
+	sources, err := conf.Sources()
+	require.NoError(t, err)
+	require.Equal(t, []string{personal, filepath.Join(eventsDir, "10-team.json"), filepath.Join(eventsDir, "20-holidays.json")}, sources)
+
+	list, err := LoadAll(sources)
+	require.NoError(t, err)
+	require.Len(t, list, 3, "the same event of a later source should replace the earlier one")
+	require.Equal(t, 7, list[1].Remind.Days, "the later source should win")
+	require.Equal(t, filepath.Join(eventsDir, "20-holidays.json"), list[1].Source)
+
+	output := &bytes.Buffer{}
+	require.NoError(t, Run(output, list, &conf))
+	want := "Today is 14 March 2022: Someone's birthday [22 year(s)] (" + personal + ")\n" +
+		"In 1 day(s) will be 2022-03-15: Colleague's birthday [32 year(s)] (" + filepath.Join(eventsDir, "10-team.json") + ")\n"
+	require.Equal(t, want, output.String(), "events should be labelled with their source")
+
+	list, err = LoadAll([]string{personal})
+	require.NoError(t, err)
+	require.Empty(t, list[0].Source, "events of a single source should not be labelled")
+
+	// Ranges of the same start and description are different events unless they end on the same day as well
+	ranges := filepath.Join(dir, "ranges.json")
+	require.NoError(t, os.WriteFile(ranges, []byte(`[
+  {"start": "2026-10-18", "end": "2026-10-20", "type": "holiday", "event": "Vacation"},
+  {"start": "2026-10-18", "end": "2026-10-25", "type": "holiday", "event": "Vacation"}
+]`), 0644))
+	list, err = LoadAll([]string{ranges})
+	require.NoError(t, err)
+	require.Len(t, list, 2, "ranges ending on different days should both be kept")
+	require.Empty(t, LintFile(ranges, time.Now()))
+
+	_, err = (&ConfigEvents{Paths: []string{eventsDir}}).File()
+	require.Error(t, err, "events of a directory cannot be changed")
+	_, err = (&ConfigEvents{Paths: []string{personal, personal}}).File()
+	require.Error(t, err, "events of several files cannot be changed")
+	path, err := (&ConfigEvents{Paths: []string{personal}}).File()
+	require.NoError(t, err)
+	require.Equal(t, personal, path)
+}
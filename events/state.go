@@ -0,0 +1,128 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is a struct to keep the day of the last run and the reminders acknowledged or snoozed between runs,
+// the latter are keyed by the event and the date of its occurrence (see occurrenceKey)
+type State struct {
+	LastRun string            `json:"last_run,omitempty"` // the day events were last reported on
+	Acks    map[string]string `json:"acks,omitempty"`     // the day the occurrence was acknowledged on
+	Snoozes map[string]string `json:"snoozes,omitempty"`  // the day the occurrence is shown again from
+}
+
+// StatePath is a function to give the path to the state file in the XDG state directory of the user:
+// $XDG_STATE_HOME/clingo/state.json, with ~/.local/state if XDG_STATE_HOME is not set
+func StatePath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "clingo", "state.json"), nil
+}
+
+// LoadState is a function to read the state file, a missing file stands for an empty state
+func LoadState(filePath string) (*State, error) {
+	s := &State{}
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf(`state file "%s": %s`, filePath, err)
+	}
+	return s, nil
+}
+
+// Save is a method to write the state file, creating its directory if needed;
+// acknowledgements of past occurrences and snoozes which are over as of today are dropped
+func (s *State) Save(filePath string, today time.Time) error {
+	day := today.Format("2006-01-02")
+	for key := range s.Acks {
+		if occurrenceDate(key) < day {
+			delete(s.Acks, key)
+		}
+	}
+	for key, until := range s.Snoozes {
+		if until <= day {
+			delete(s.Snoozes, key)
+		}
+	}
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return err
+	}
+	return writeFile(filePath, append(content, '\n'))
+}
+
+// Ack is a method to acknowledge the occurrence today: its reminders are hidden until it occurs,
+// an occurrence acknowledged on its day is hidden for the rest of the day
+func (s *State) Ack(o Occurrence, today time.Time) {
+	if s.Acks == nil {
+		s.Acks = make(map[string]string)
+	}
+	s.Acks[occurrenceKey(o)] = today.Format("2006-01-02")
+}
+
+// Snooze is a method to hide the occurrence until the given day
+func (s *State) Snooze(o Occurrence, until time.Time) {
+	if s.Snoozes == nil {
+		s.Snoozes = make(map[string]string)
+	}
+	s.Snoozes[occurrenceKey(o)] = until.Format("2006-01-02")
+}
+
+// Hides is a method to check the occurrence is acknowledged or snoozed as of today, nothing is hidden without a state
+func (s *State) Hides(o Occurrence, today time.Time) bool {
+	if s == nil {
+		return false
+	}
+	day := today.Format("2006-01-02")
+	key := occurrenceKey(o)
+	if acked, ok := s.Acks[key]; ok && (o.Days > 0 || acked == day) {
+		return true
+	}
+	until, ok := s.Snoozes[key]
+	return ok && day < until
+}
+
+// Since is a method to give the day events were last reported on, the second returned value is false
+// if there is no state or it has no valid day of the last run
+func (s *State) Since() (time.Time, bool) {
+	if s == nil || s.LastRun == "" {
+		return time.Time{}, false
+	}
+	since, err := time.Parse("2006-01-02", s.LastRun)
+	return since, err == nil
+}
+
+// occurrenceKey is a function to give the key an occurrence is kept in the state by:
+// the key of the event (see mergeKey) followed by the date of the occurrence
+func occurrenceKey(o Occurrence) string {
+	return mergeKey(o.Event) + "|" + o.Date.Format("2006-01-02")
+}
+
+// occurrenceDate is a function to give the date of the occurrence (YYYY-MM-DD) the key is for
+func occurrenceDate(key string) string {
+	if len(key) < len("2006-01-02") {
+		return ""
+	}
+	return key[len(key)-len("2006-01-02"):]
+}
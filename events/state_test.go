@@ -0,0 +1,111 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "03-15", Year: 2010, Remind: structs.Remind{Days: 3}, Type: "anniversary", Event: "Someone's anniversary"},
+	}
+	day := func(d int) time.Time {
+		return time.Date(2026, time.March, d, 9, 0, 0, 0, time.UTC)
+	}
+	path := filepath.Join(t.TempDir(), "clingo", "state.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	conf := &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: day(12), State: state}
+	reported := Reported(list, conf)
+	require.Len(t, reported, 2)
+	state.Ack(reported[0], day(12))
+	state.Snooze(reported[1], day(14))
+	require.NoError(t, state.Save(path, day(12)))
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		wantOut string
+	}{
+		{"acknowledged and snoozed", day(12), "No events today.\nNo reminders today.\n"},
+		{"acknowledged until the event", day(13), "No events today.\nNo reminders today.\n"},
+		{"event of an acknowledged reminder", day(14), "Today is 14 March 2026: Someone's birthday [26 year(s)]\nIn 1 day(s) will be 2026-03-15: Someone's anniversary [16 year(s)]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := LoadState(path)
+			require.NoError(t, err)
+			out := &bytes.Buffer{}
+			conf := &ConfigEvents{Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: tt.today, State: state}
+			require.NoError(t, Run(out, list, conf))
+			require.Equal(t, tt.wantOut, out.String())
+		})
+	}
+
+	// Past acknowledgements and snoozes which are over are dropped
+	require.NoError(t, state.Save(path, day(15)))
+	state, err = LoadState(path)
+	require.NoError(t, err)
+	require.Equal(t, &State{}, state)
+}
+
+func TestRunMissed(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "03-17", Year: 2010, Remind: structs.Remind{Days: 3}, Type: "anniversary", Event: "Someone's anniversary"},
+		{Start: "2026-03-13", End: "2026-03-15", Type: "holiday", Event: "Long weekend"},
+		{Date: "01-01", Year: 2000, Type: "holiday", Event: "Too long ago"},
+	}
+	today := time.Date(2026, time.March, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		state   *State
+		filter  string
+		wantOut string
+	}{
+		{"no state", nil, "", "In 1 day(s) will be 2026-03-17: Someone's anniversary [16 year(s)]\n"},
+		{"first run", &State{}, "", "In 1 day(s) will be 2026-03-17: Someone's anniversary [16 year(s)]\n"},
+		{
+			"long weekend",
+			&State{LastRun: "2026-03-12"},
+			"",
+			"Since your last check on 2026-03-12:\n" +
+				"  2026-03-13: Long weekend [3 day(s)]\n" +
+				"  2026-03-14: Someone's birthday [26 year(s)]\n" +
+				"In 1 day(s) will be 2026-03-17: Someone's anniversary [16 year(s)]\n",
+		},
+		{
+			"filter",
+			&State{LastRun: "2025-12-31"},
+			"birthday",
+			"Since your last check on 2025-12-31:\n" +
+				"  2026-03-14: Someone's birthday [26 year(s)]\n" +
+				"No events today.\nNo reminders today.\n",
+		},
+		{"checked on the day of the event", &State{LastRun: "2026-03-14"}, "birthday", "No events today.\nNo reminders today.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			conf := &ConfigEvents{Filter: tt.filter, Horizon: 10, LeapDay: helpers.LeapDayFeb28, Today: today, State: tt.state}
+			require.NoError(t, Run(out, list, conf))
+			require.Equal(t, tt.wantOut, out.String())
+		})
+	}
+
+	// An event of the previous year observed in this one is missed on the day it is observed on
+	list = []structs.EventMetadata{{Date: "12-31", Observe: helpers.ObserveNextMonday, Type: "holiday", Event: "New Year's Eve"}}
+	conf := &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: time.Date(2023, time.January, 5, 9, 0, 0, 0, time.UTC)}
+	missed := Missed(list, conf, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, missed, 1)
+	require.Equal(t, time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), missed[0].Date)
+}
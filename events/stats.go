@@ -0,0 +1,143 @@
+package events
+
+import (
+	"clingo/structs"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Numbers of entries of the sections of the statistics
+const (
+	statsBusiestWeeks = 3
+	statsUpcoming     = 10
+)
+
+// week is a struct to keep the number of occurrences of events in an ISO week
+type week struct {
+	Year, Week int
+	Monday     time.Time
+	Count      int
+}
+
+// RunStats is a function to print statistics of the events of the type filtered, if any, for the year of today:
+// the number of events per type, occurrences per month and the busiest weeks of the year, the next upcoming events,
+// the events without a year and the ages reached by birthdays this year by decade
+func RunStats(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	var filtered []structs.EventMetadata
+	for _, e := range list {
+		if conf.Filter == "" || e.Type == conf.Filter {
+			filtered = append(filtered, e)
+		}
+	}
+	year := conf.Today.Year()
+	output := fmt.Sprintf("Events: %d\n", len(filtered))
+
+	types := make(map[string]int)
+	var names []string
+	for _, e := range filtered {
+		if types[e.Type] == 0 {
+			names = append(names, e.Type)
+		}
+		types[e.Type]++
+	}
+	sort.Strings(names)
+	output += "\nBy type:\n" + none(len(names))
+	for _, name := range names {
+		output += fmt.Sprintf("  %-12s %3d\n", name, types[name])
+	}
+
+	// Ranges occur once in every month they last in, but only in the week they start in
+	weeks := make(map[string]*week)
+	output += fmt.Sprintf("\nBy month in %d:\n", year)
+	for m := time.January; m <= time.December; m++ {
+		occurrences := InMonth(filtered, year, m, conf.LeapDay)
+		output += fmt.Sprintf("  %-12s %3d\n", m, len(occurrences))
+		for _, o := range occurrences {
+			if o.Day > 1 {
+				continue
+			}
+			y, w := o.Date.ISOWeek()
+			key := fmt.Sprintf("%04d-%02d", y, w)
+			if weeks[key] == nil {
+				weekday := (int(o.Date.Weekday()) + 6) % 7
+				weeks[key] = &week{Year: y, Week: w, Monday: o.Date.AddDate(0, 0, -weekday)}
+			}
+			weeks[key].Count++
+		}
+	}
+
+	busiest := make([]*week, 0, len(weeks))
+	for _, w := range weeks {
+		busiest = append(busiest, w)
+	}
+	sort.Slice(busiest, func(i, j int) bool {
+		if busiest[i].Count != busiest[j].Count {
+			return busiest[i].Count > busiest[j].Count
+		}
+		return busiest[i].Monday.Before(busiest[j].Monday)
+	})
+	if len(busiest) > statsBusiestWeeks {
+		busiest = busiest[:statsBusiestWeeks]
+	}
+	output += fmt.Sprintf("\nBusiest weeks in %d:\n", year) + none(len(busiest))
+	for _, w := range busiest {
+		output += fmt.Sprintf("  week %d of %d (%s..%s): %d event(s)\n", w.Week, w.Year,
+			w.Monday.Format("2006-01-02"), w.Monday.AddDate(0, 0, 6).Format("2006-01-02"), w.Count)
+	}
+
+	upcoming := Find(filtered, conf, "")
+	if len(upcoming) > statsUpcoming {
+		upcoming = upcoming[:statsUpcoming]
+	}
+	output += fmt.Sprintf("\nNext %d event(s):\n", len(upcoming)) + none(len(upcoming))
+	for _, o := range upcoming {
+		output += fmt.Sprintf("  %s %s: %s%s%s\n",
+			o.Date.Format("2006-01-02"), countdown(o.Days), o.Event.Event, details(o), label(o.Event))
+	}
+
+	yearless := ""
+	count := 0
+	for _, e := range filtered {
+		if e.Year == 0 && e.Start == "" {
+			yearless += fmt.Sprintf("  %s%s\n", e.Event, label(e))
+			count++
+		}
+	}
+	output += "\nEvents without a year:\n" + none(count) + yearless
+
+	decades := make(map[int]int)
+	var first, last int
+	for _, e := range filtered {
+		if e.Type != "birthday" || e.Year <= 0 || e.Start != "" || e.Year > year {
+			continue
+		}
+		decade := (year - e.Year) / 10 * 10
+		if len(decades) == 0 || decade < first {
+			first = decade
+		}
+		if len(decades) == 0 || decade > last {
+			last = decade
+		}
+		decades[decade]++
+	}
+	output += fmt.Sprintf("\nAges of birthdays in %d:\n", year) + none(len(decades))
+	for decade := first; len(decades) > 0 && decade <= last; decade += 10 {
+		output += fmt.Sprintf("  %-12s %3d\n", fmt.Sprintf("%d-%d", decade, decade+9), decades[decade])
+	}
+
+	_, _ = fmt.Fprint(out, "", output)
+	return nil
+}
+
+// none is a function to give the line of an empty section of the statistics, given the number of its entries
+func none(entries int) string {
+	if entries == 0 {
+		return "  none\n"
+	}
+	return ""
+}
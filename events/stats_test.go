@@ -0,0 +1,66 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStats(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "03-16", Year: 1976, Type: "birthday", Event: "Someone else's birthday"},
+		{Date: "11-05", Type: "birthday", Event: "Birthday of an unknown year"},
+		{Date: "03-20", Year: 2016, Type: "anniversary", Event: "Work anniversary"},
+		{Start: "2026-02-27", End: "2026-03-03", Type: "holiday", Event: "Ski trip"},
+		{Date: "12-25", Type: "holiday", Event: "Christmas"},
+	}
+	today := time.Date(2026, time.March, 15, 9, 0, 0, 0, time.UTC)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, RunStats(out, list, &ConfigEvents{LeapDay: helpers.LeapDayFeb28, Today: today}))
+	require.Equal(t, "Events: 6\n"+
+		"\nBy type:\n"+
+		"  anniversary    1\n"+
+		"  birthday       3\n"+
+		"  holiday        2\n"+
+		"\nBy month in 2026:\n"+
+		"  January        0\n"+
+		"  February       1\n"+
+		"  March          4\n"+
+		"  April          0\n"+
+		"  May            0\n"+
+		"  June           0\n"+
+		"  July           0\n"+
+		"  August         0\n"+
+		"  September      0\n"+
+		"  October        0\n"+
+		"  November       1\n"+
+		"  December       1\n"+
+		"\nBusiest weeks in 2026:\n"+
+		"  week 12 of 2026 (2026-03-16..2026-03-22): 2 event(s)\n"+
+		"  week 9 of 2026 (2026-02-23..2026-03-01): 1 event(s)\n"+
+		"  week 11 of 2026 (2026-03-09..2026-03-15): 1 event(s)\n"+
+		"\nNext 5 event(s):\n"+
+		"  2026-03-16 in 1 day: Someone else's birthday [50 year(s)]\n"+
+		"  2026-03-20 in 5 days: Work anniversary [10 year(s)]\n"+
+		"  2026-11-05 in 235 days: Birthday of an unknown year\n"+
+		"  2026-12-25 in 285 days: Christmas\n"+
+		"  2027-03-14 in 364 days: Someone's birthday [27 year(s)]\n"+
+		"\nEvents without a year:\n"+
+		"  Birthday of an unknown year\n"+
+		"  Christmas\n"+
+		"\nAges of birthdays in 2026:\n"+
+		"  20-29          1\n"+
+		"  30-39          0\n"+
+		"  40-49          0\n"+
+		"  50-59          1\n", out.String())
+
+	out.Reset()
+	require.NoError(t, RunStats(out, list, &ConfigEvents{Filter: "party", LeapDay: helpers.LeapDayFeb28, Today: today}))
+	require.Contains(t, out.String(), "Events: 0\n\nBy type:\n  none\n")
+}
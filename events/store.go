@@ -0,0 +1,141 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Open is a function to load the events file for changes, sorted the same way Save writes it,
+// so that positions of events stay the same. A missing file gives an empty list of events.
+func Open(filePath string) ([]structs.EventMetadata, error) {
+	if Format(filePath) != FormatJSON {
+		return nil, fmt.Errorf(`events file "%s" can only be changed in JSON format`, filePath)
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	list, err := Load(filePath)
+	Sort(list)
+	return list, err
+}
+
+// Save is a function to write the events file in the list format, sorted by calendar day.
+// The file is replaced atomically: the events are written to a temporary file which is renamed afterwards.
+func Save(filePath string, list []structs.EventMetadata) error {
+	Sort(list)
+
+	var b bytes.Buffer
+	b.WriteString("[\n")
+	for i, e := range list {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		b.WriteString("  ")
+		b.Write(line)
+		if i < len(list)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+
+	return writeFile(filePath, b.Bytes())
+}
+
+// writeFile is a function to replace the content of the file atomically keeping its permissions,
+// the file is created if it does not exist
+func writeFile(filePath string, content []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filePath); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".clingo-*")
+	if err != nil {
+		return err
+	}
+	defer func(name string) {
+		_ = os.Remove(name) // no-op once the file is renamed
+	}(tmp.Name())
+
+	if _, err = tmp.Write(content); err == nil {
+		err = tmp.Sync()
+	}
+	if e := tmp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+// Sort is a function to sort events by calendar day: fixed days and recurrence rules are ordered
+// by the first day they fall on in a leap year, one-off events and ranges by the day they start on,
+// events of the same day keep their order.
+func Sort(list []structs.EventMetadata) {
+	sort.SliceStable(list, func(i, j int) bool {
+		return sortKey(list[i]) < sortKey(list[j])
+	})
+}
+
+// sortKey is a function to give the "MM-DD" day the event is sorted by
+func sortKey(e structs.EventMetadata) string {
+	if start, _, err := span(e); err == nil {
+		return start.Format("01-02")
+	}
+	if tm, ok := firstDate(e, 2000, helpers.LeapDaySkip); ok {
+		return tm.Format("01-02")
+	}
+	return e.Date
+}
+
+// Validate is a function to check the event can be stored in the events file, it returns the first problem found
+func Validate(e structs.EventMetadata) error {
+	if problems := Problems(e); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// when is a function to give the day(s) of the event as listed: its date, recurrence rule or range, e.g. "03-14..03-16"
+func when(e structs.EventMetadata) string {
+	day := e.Date
+	if e.Recurrence != "" {
+		day = e.Recurrence
+	}
+	if e.Start != "" {
+		day = e.Start
+	}
+	if e.End != "" {
+		day += ".." + e.End
+	}
+	return day
+}
+
+// Describe is a function to give a single line description of the event, used for listing events
+func Describe(e structs.EventMetadata) string {
+	day := when(e)
+	if e.Calendar != "" && e.Calendar != helpers.CalendarGregorian {
+		day += " (" + e.Calendar + ")"
+	}
+	if e.Observe != "" && e.Observe != helpers.ObserveNone {
+		day += " (observed " + e.Observe + ")"
+	}
+	remind := e.Remind.String()
+	if e.BusinessDays {
+		remind += " business days"
+	}
+	return fmt.Sprintf("%s: %s [%s, year %d, remind %s]%s", day, e.Event, e.Type, e.Year, remind, label(e))
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"clingo/structs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveOpen(t *testing.T) {
+	eventsFileName := filepath.Join(t.TempDir(), "events.json")
+
+	list, err := Open(eventsFileName)
+	require.NoError(t, err, "a missing events file should give no events")
+	require.Empty(t, list)
+
+	list = []structs.EventMetadata{
+		{Date: "12-25", Year: 1, Type: "holiday", Event: "Catholic Christmas Day"},
+		{Recurrence: "2nd Sunday of May", Year: 1908, Remind: structs.Remind{Days: 3}, Type: "holiday", Event: "Mother's Day"},
+		{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "03-14", Year: 1990, Remind: structs.Remind{Days: 1}, Type: "birthday", Event: "Another birthday"},
+	}
+	require.NoError(t, Save(eventsFileName, list))
+
+	content, err := os.ReadFile(eventsFileName)
+	require.NoError(t, err)
+	want := `[
+  {"date":"03-14","year":2000,"remind":3,"type":"birthday","event":"Someone's birthday"},
+  {"date":"03-14","year":1990,"remind":1,"type":"birthday","event":"Another birthday"},
+  {"recurrence":"2nd Sunday of May","year":1908,"remind":3,"type":"holiday","event":"Mother's Day"},
+  {"date":"12-25","year":1,"remind":0,"type":"holiday","event":"Catholic Christmas Day"}
+]
+`
+	require.Equal(t, want, string(content), "events should be sorted by calendar day")
+
+	got, err := Open(eventsFileName)
+	require.NoError(t, err)
+	if !reflect.DeepEqual(got, list) {
+		t.Errorf("Open() got = %v, want %v", got, list)
+	}
+
+	_, err = Open(filepath.Join(t.TempDir(), "events.ics"))
+	require.Error(t, err, "only JSON events files can be changed")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   structs.EventMetadata
+		wantErr bool
+	}{
+		{"ok", structs.EventMetadata{Date: "03-14", Year: 2000, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Birthday"}, false},
+		{"leap day", structs.EventMetadata{Date: "02-29", Type: "birthday", Event: "Birthday"}, false},
+		{"recurrence", structs.EventMetadata{Recurrence: "Easter", Type: "holiday", Event: "Easter"}, false},
+		{"impossible date", structs.EventMetadata{Date: "02-31", Type: "birthday", Event: "Birthday"}, true},
+		{"malformed date", structs.EventMetadata{Date: "3-14", Type: "birthday", Event: "Birthday"}, true},
+		{"no date", structs.EventMetadata{Type: "birthday", Event: "Birthday"}, true},
+		{"bad recurrence", structs.EventMetadata{Recurrence: "daily", Type: "holiday", Event: "Daily"}, true},
+		{"no description", structs.EventMetadata{Date: "03-14", Type: "birthday"}, true},
+		{"negative year", structs.EventMetadata{Date: "03-14", Year: -1, Type: "birthday", Event: "Birthday"}, true},
+		{"negative remind", structs.EventMetadata{Date: "03-14", Remind: structs.Remind{Days: -1}, Type: "birthday", Event: "Birthday"}, true},
+		{"unknown type", structs.EventMetadata{Date: "03-14", Type: "party", Event: "Party"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.event); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
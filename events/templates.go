@@ -0,0 +1,129 @@
+package events
+
+import (
+	"bytes"
+	"clingo/structs"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// greetings is a map of event types to the templates of greetings used unless configured otherwise
+var greetings = map[string]string{
+	"birthday":    "Happy {{if .Age}}{{.Ordinal}} {{end}}birthday, {{.Name}}!",
+	"anniversary": "Happy {{if .Age}}{{.Ordinal}} {{end}}anniversary, {{.Name}}!",
+	"":            "Happy {{.Event}}!",
+}
+
+// Message is a struct to keep the fields of an occurrence available to the templates of report lines and greetings
+type Message struct {
+	Event     string    // the description of the event, e.g. "Someone's birthday"
+	Name      string    // the description without the type, e.g. "Someone"
+	Type      string    // the type of the event
+	Date      time.Time // the date of the occurrence
+	DaysUntil int       // days from today until the occurrence
+	Age       int       // years since the year of the event on the occurrence, zero if the year is unknown
+	Ordinal   string    // the age as an ordinal number, e.g. "35th"
+	Years     int       // years since the year of the event today, one less than the age before the occurrence
+	Milestone bool      // the age is one of the milestones
+	Source    string    // the file the event comes from if events are merged from several files
+}
+
+// newMessage is a function to give the fields of the occurrence for the templates
+func newMessage(o Occurrence) Message {
+	e := o.Event
+	m := Message{Event: e.Event, Name: name(e.Event, e.Type), Type: e.Type, Date: o.Date, DaysUntil: o.Days,
+		Milestone: o.Milestone, Source: e.Source}
+	if e.Year > 0 && e.Start == "" {
+		m.Age = o.Age()
+		m.Ordinal = ordinal(m.Age)
+		m.Years = m.Age
+		if o.Days > 0 && m.Years > 0 {
+			m.Years--
+		}
+	}
+	return m
+}
+
+// name is a function to give the description of the event without its type, e.g. "Someone" of "Someone's birthday"
+func name(event string, eventType string) string {
+	for _, suffix := range []string{"'s " + eventType, "’s " + eventType} {
+		if eventType != "" && len(event) > len(suffix) && strings.EqualFold(event[len(event)-len(suffix):], suffix) {
+			return event[:len(event)-len(suffix)]
+		}
+	}
+	return event
+}
+
+// ordinal is a function to write the number as an ordinal one, e.g. "1st", "12th", "22nd"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// checkTemplates is a function to verify the templates by event type are valid Go templates
+func checkTemplates(kind string, templates map[string]string) error {
+	for eventType, text := range templates {
+		if _, err := template.New(eventType).Parse(text); err != nil {
+			return fmt.Errorf(`invalid %s template of "%s": %s`, kind, eventType, err)
+		}
+	}
+	return nil
+}
+
+// render is a function to execute the template with the fields of the occurrence
+func render(text string, o Occurrence) (string, error) {
+	t, err := template.New(o.Event.Type).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err = t.Execute(&b, newMessage(o)); err != nil {
+		return "", fmt.Errorf(`event "%s": %s`, o.Event.Event, err)
+	}
+	return b.String(), nil
+}
+
+// RunGreet is a function to print ready-to-send greetings for today's events of the type filtered, if any,
+// and matching the text, if given (see Matches). The greeting template of the type of the event is used,
+// the built-in greeting otherwise.
+func RunGreet(out io.Writer, list []structs.EventMetadata, conf *ConfigEvents, text string) error {
+	if err := conf.Check(); err != nil {
+		return err
+	}
+	output := ""
+	for _, o := range Upcoming(list, conf, 0) {
+		e := o.Event
+		if (conf.Filter != "" && e.Type != conf.Filter) || (text != "" && !Matches(e, text)) {
+			continue
+		}
+		greeting, ok := conf.Greetings[e.Type]
+		if !ok {
+			if greeting, ok = greetings[e.Type]; !ok {
+				greeting = greetings[""]
+			}
+		}
+		line, err := render(greeting, o)
+		if err != nil {
+			return err
+		}
+		output += line + "\n"
+	}
+	if output == "" {
+		return fmt.Errorf("no events today to greet")
+	}
+
+	_, _ = fmt.Fprint(out, "", output)
+	return nil
+}
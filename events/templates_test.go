@@ -0,0 +1,88 @@
+package events
+
+import (
+	"bytes"
+	"clingo/helpers"
+	"clingo/structs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTemplates(t *testing.T) {
+	list := []structs.EventMetadata{
+		{Date: "03-14", Year: 1991, Remind: structs.Remind{Days: 3}, Type: "birthday", Event: "Someone's birthday"},
+		{Date: "03-15", Year: 2014, Remind: structs.Remind{Days: 3}, Type: "anniversary", Event: "Wedding anniversary"},
+		{Date: "03-14", Year: 1879, Type: "holiday", Event: "Pi Day"},
+		{Date: "03-14", Type: "birthday", Event: "Jane’s birthday"},
+	}
+	today := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	templates := map[string]string{
+		"birthday":    "{{.Name}} is {{.Age}} today",
+		"anniversary": "{{.Event}} in {{.DaysUntil}} day(s): {{.Ordinal}}, {{.Years}} year(s) so far",
+	}
+
+	tests := []struct {
+		name      string
+		run       func(out *bytes.Buffer, conf *ConfigEvents) error
+		greetings map[string]string
+		wantOut   string
+		wantErr   bool
+	}{
+		{
+			"report lines",
+			func(out *bytes.Buffer, conf *ConfigEvents) error { return Run(out, list, conf) },
+			nil,
+			"Someone is 35 today\nToday is 14 March 2026: Pi Day [147 year(s)]\nJane is 0 today\n" +
+				"Wedding anniversary in 1 day(s): 12th, 11 year(s) so far\n",
+			false,
+		},
+		{
+			"built-in greetings",
+			func(out *bytes.Buffer, conf *ConfigEvents) error { return RunGreet(out, list, conf, "") },
+			nil,
+			"Happy 35th birthday, Someone!\nHappy Pi Day!\nHappy birthday, Jane!\n",
+			false,
+		},
+		{
+			"configured greeting",
+			func(out *bytes.Buffer, conf *ConfigEvents) error { return RunGreet(out, list, conf, "someone") },
+			map[string]string{"birthday": "Dear {{.Name}}, congratulations on your {{.Ordinal}}!"},
+			"Dear Someone, congratulations on your 35th!\n",
+			false,
+		},
+		{
+			"invalid template",
+			func(out *bytes.Buffer, conf *ConfigEvents) error { return RunGreet(out, list, conf, "") },
+			map[string]string{"birthday": "{{.Name"},
+			"",
+			true,
+		},
+		{
+			"unknown field",
+			func(out *bytes.Buffer, conf *ConfigEvents) error { return RunGreet(out, list, conf, "") },
+			map[string]string{"birthday": "{{.Nickname}}"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			conf := &ConfigEvents{Horizon: 3, LeapDay: helpers.LeapDayFeb28, Today: today, Templates: templates, Greetings: tt.greetings}
+			err := tt.run(out, conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			require.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 101: "101st", 111: "111th", 112: "112th"} {
+		require.Equal(t, want, ordinal(n))
+	}
+}
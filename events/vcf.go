@@ -0,0 +1,128 @@
+package events
+
+import (
+	"clingo/structs"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vcfDate is a regular expression to match the date of vCard 3 ("1996-04-15") and vCard 4 ("19960415") values,
+// with the year omitted ("--0415", "--04-15") and a time possibly following
+var vcfDate = regexp.MustCompile(`^(\d{4}|--)-?(\d{2})-?(\d{2})(?:T.*)?$`)
+
+// vcfDates is a map of vCard properties to the types of events they stand for
+var vcfDates = map[string]string{
+	"BDAY":          "birthday",
+	"ANNIVERSARY":   "anniversary",
+	"X-ANNIVERSARY": "anniversary",
+}
+
+// ParseVCF is a function to load events from the contacts of vCard content (vCard 3 and 4):
+// the birthday (BDAY) and the anniversary (ANNIVERSARY or X-ANNIVERSARY) of every contact become events
+// reminded of as given, named after the formatted name (FN) of the contact or its structured name (N).
+// Dates with the year omitted (e.g. "--04-15") give events without a year, as do those with the year
+// Apple contacts mark as omitted. Dates given as text (e.g. "circa 1800") are skipped.
+// Content without any contact is not a vCard file and is rejected.
+func ParseVCF(content []byte, remind structs.Remind) ([]structs.EventMetadata, error) {
+	var list []structs.EventMetadata
+	var name, fallback string
+	var dates []icsProperty
+	inside, found := false, false
+
+	for _, line := range unfoldICS(content) {
+		p, err := parseICSLine(line)
+		if err != nil {
+			return nil, err
+		}
+		// Properties may be grouped, e.g. "item1.X-ANNIVERSARY"
+		if i := strings.LastIndexByte(p.Name, '.'); i >= 0 {
+			p.Name = p.Name[i+1:]
+		}
+		switch {
+		case p.Name == "BEGIN" && strings.EqualFold(p.Value, "VCARD"):
+			inside, found, name, fallback, dates = true, true, "", "", nil
+		case !inside:
+			continue
+		case p.Name == "END" && strings.EqualFold(p.Value, "VCARD"):
+			inside = false
+			if name == "" {
+				name = fallback
+			}
+			for _, d := range dates {
+				e, ok, err := vcfEvent(name, d, remind)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					list = append(list, e)
+				}
+			}
+		case p.Name == "FN":
+			name = strings.TrimSpace(unescapeICS(p.Value))
+		case p.Name == "N":
+			fallback = vcfName(p.Value)
+		case vcfDates[p.Name] != "":
+			dates = append(dates, p)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no contacts found, expected vCard content with BEGIN:VCARD")
+	}
+
+	return list, nil
+}
+
+// vcfEvent is a function to convert a date property of the contact into an event,
+// the second returned value is false if the date is given as text
+func vcfEvent(name string, p icsProperty, remind structs.Remind) (structs.EventMetadata, bool, error) {
+	e := structs.EventMetadata{Remind: remind, Type: vcfDates[p.Name]}
+	if strings.EqualFold(p.Params["VALUE"], "TEXT") {
+		return e, false, nil
+	}
+	if name == "" {
+		return e, false, fmt.Errorf(`contact without a name has %s "%s"`, p.Name, p.Value)
+	}
+	m := vcfDate.FindStringSubmatch(strings.TrimSpace(p.Value))
+	if m == nil {
+		return e, false, fmt.Errorf(`contact "%s" has malformed %s "%s"`, name, p.Name, p.Value)
+	}
+	e.Date = m[2] + "-" + m[3]
+	if m[1] != "--" && m[1] != p.Params["X-APPLE-OMIT-YEAR"] {
+		e.Year, _ = strconv.Atoi(m[1])
+	}
+	e.Event = fmt.Sprintf("%s's %s", name, e.Type)
+	return e, true, Validate(e)
+}
+
+// vcfName is a function to give the name of a contact from its structured name "Family;Given;Additional;Prefix;Suffix"
+func vcfName(value string) string {
+	parts := strings.Split(value, ";")
+	var names []string
+	for _, i := range []int{3, 1, 2, 0, 4} {
+		if i < len(parts) && strings.TrimSpace(parts[i]) != "" {
+			names = append(names, strings.TrimSpace(unescapeICS(parts[i])))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// Import is a function to add the imported events to the list unless it has them already,
+// i.e. an event on the same day with the same description; the number of events added is returned
+func Import(list []structs.EventMetadata, imported []structs.EventMetadata) ([]structs.EventMetadata, int) {
+	known := make(map[string]bool)
+	for _, e := range list {
+		known[mergeKey(e)] = true
+	}
+	added := 0
+	for _, e := range imported {
+		if known[mergeKey(e)] {
+			continue
+		}
+		known[mergeKey(e)] = true
+		list = append(list, e)
+		added++
+	}
+	return list, added
+}
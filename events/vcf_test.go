@@ -0,0 +1,67 @@
+package events
+
+import (
+	"clingo/structs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVCF(t *testing.T) {
+	content := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Doe;Jane;;;\r\n" +
+		"FN:Jane Doe\r\n" +
+		"BDAY:1985-03-14\r\n" +
+		"item1.X-ANNIVERSARY:2010-06-19\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"N:Smith;John;;Dr.;\r\n" +
+		"BDAY:--0704\r\n" +
+		"ANNIVERSARY;VALUE=text:circa 1800\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Someone \r\n" +
+		" Else\r\n" +
+		"BDAY:20000229T080000Z\r\n" +
+		"ANNIVERSARY:--12-01\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:No Year\r\n" +
+		"BDAY;X-APPLE-OMIT-YEAR=1604:1604-11-05\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"FN:No Dates\r\n" +
+		"END:VCARD\r\n"
+
+	remind := structs.Remind{Days: 7}
+	got, err := ParseVCF([]byte(content), remind)
+	require.NoError(t, err)
+	require.Equal(t, []structs.EventMetadata{
+		{Date: "03-14", Year: 1985, Remind: remind, Type: "birthday", Event: "Jane Doe's birthday"},
+		{Date: "06-19", Year: 2010, Remind: remind, Type: "anniversary", Event: "Jane Doe's anniversary"},
+		{Date: "07-04", Remind: remind, Type: "birthday", Event: "Dr. John Smith's birthday"},
+		{Date: "02-29", Year: 2000, Remind: remind, Type: "birthday", Event: "Someone Else's birthday"},
+		{Date: "12-01", Remind: remind, Type: "anniversary", Event: "Someone Else's anniversary"},
+		{Date: "11-05", Remind: remind, Type: "birthday", Event: "No Year's birthday"},
+	}, got)
+
+	// Events the list has already are not imported again
+	list, added := Import(append([]structs.EventMetadata{}, got[:2]...), got)
+	require.Equal(t, 4, added)
+	require.Equal(t, got, list)
+
+	for _, content := range []string{
+		"BEGIN:VCARD\nFN:Someone\nBDAY:14 March\nEND:VCARD\n",
+		"BEGIN:VCARD\nFN:Someone\nBDAY:--0231\nEND:VCARD\n",
+		"BEGIN:VCARD\nBDAY:--0314\nEND:VCARD\n",
+		"BEGIN:VCARD\nmalformed\nEND:VCARD\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Wedding\nDTSTART;VALUE=DATE:20120411\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n",
+	} {
+		_, err := ParseVCF([]byte(content), remind)
+		require.Error(t, err, content)
+	}
+}
@@ -0,0 +1,263 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Calendars events can be given in, "MM-DD" days of the events are days of the calendar
+const (
+	CalendarGregorian = "gregorian"
+	CalendarJulian    = "julian"  // e.g. the Orthodox Christmas on "12-25"
+	CalendarHebrew    = "hebrew"  // months are counted from Nisan, e.g. Hanukkah on Kislev 25 is "09-25"
+	CalendarIslamic   = "islamic" // the tabular (arithmetical) calendar, e.g. the 1st of Ramadan is "09-01"
+	CalendarChinese   = "chinese" // the lunisolar calendar of China, e.g. the Chinese New Year is "01-01"
+)
+
+// Calendars is a list of supported calendars
+var Calendars = []string{CalendarGregorian, CalendarJulian, CalendarHebrew, CalendarIslamic, CalendarChinese}
+
+// Fixed day numbers count days since December 31 of the year 0 (proleptic Gregorian), i.e. January 1 of the year 1 is 1
+const (
+	unixEpochFixed = 719163   // January 1, 1970
+	julianEpoch    = -1       // January 1 of the year 1 in the Julian calendar
+	islamicEpoch   = 227015   // July 16, 622 (Julian), the 1st of Muharram of the year 1
+	hebrewEpoch    = -1373427 // October 7, 3761 BCE (Julian), the 1st of Tishrei of the year 1
+)
+
+// Hebrew months are counted from Nisan, which is the 7th month since Tishrei, the start of the year
+const (
+	hebrewTishrei = 7
+	hebrewAdar    = 12
+	hebrewAdarII  = 13
+)
+
+// KnownCalendar is a function to check the calendar is supported, an empty one stands for the Gregorian calendar
+func KnownCalendar(calendar string) bool {
+	if calendar == "" {
+		return true
+	}
+	for _, c := range Calendars {
+		if calendar == c {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckMonthDay is a function to verify the "<month>-<day>" string is a day of the calendar:
+// Gregorian and Julian days must exist in a leap year, days of lunar months go up to 30
+// and they are skipped in the years their months are shorter.
+func CheckMonthDay(calendar string, monthDay string) error {
+	if calendar == "" || calendar == CalendarGregorian || calendar == CalendarJulian {
+		// The year 2000 is a leap one in both calendars, so "02-29" is accepted
+		if _, ok := DateOfMonthDay(monthDay, 2000); !ok {
+			return fmt.Errorf(`invalid date "%s", expected "MM-DD"`, monthDay)
+		}
+		return nil
+	}
+	var month, day int
+	if n, err := fmt.Sscanf(monthDay, "%02d-%02d", &month, &day); err != nil || n != 2 || len(monthDay) != 5 ||
+		month < 1 || month > 12 || day < 1 || day > 30 {
+		return fmt.Errorf(`invalid %s date "%s", expected "MM-DD" with a month up to 12 and a day up to 30`, calendar, monthDay)
+	}
+	return nil
+}
+
+// CalendarDates is a function to calculate the Gregorian dates the "<month>-<day>" of the calendar falls on
+// in the given Gregorian year, sorted. Lunar years are shorter than Gregorian ones, so an Islamic day may happen
+// twice a year; days which do not exist in a year of the calendar (e.g. the 30th of a short month) are skipped.
+func CalendarDates(calendar string, monthDay string, year int) []time.Time {
+	if err := CheckMonthDay(calendar, monthDay); err != nil {
+		return nil
+	}
+	var month, day int
+	_, _ = fmt.Sscanf(monthDay, "%02d-%02d", &month, &day)
+
+	// Years of the calendar which may overlap the Gregorian year
+	var first, last int
+	var convert func(y int) (int, bool)
+	switch calendar {
+	case CalendarJulian:
+		first, last = year-1, year
+		convert = func(y int) (int, bool) { return fixedFromJulian(y, month, day) }
+	case CalendarHebrew:
+		first, last = year+3760, year+3761
+		convert = func(y int) (int, bool) { return fixedFromHebrew(y, month, day) }
+	case CalendarIslamic:
+		approx := (year-622)*33/32 + 1
+		first, last = approx-1, approx+2
+		convert = func(y int) (int, bool) { return fixedFromIslamic(y, month, day) }
+	case CalendarChinese:
+		first, last = year-1, year
+		convert = func(y int) (int, bool) { return fixedFromChinese(y, month, day) }
+	default:
+		if tm, ok := DateOfMonthDay(monthDay, year); ok {
+			return []time.Time{tm}
+		}
+		return nil
+	}
+
+	var dates []time.Time
+	for y := first; y <= last; y++ {
+		if fixed, ok := convert(y); ok {
+			if tm := dateOfFixed(fixed); tm.Year() == year {
+				dates = append(dates, tm)
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// JulianEaster is a function to calculate the Gregorian date of Easter Sunday of Eastern Orthodox churches
+// in the given year, it implements the Meeus algorithm for the Julian calendar.
+func JulianEaster(year int) time.Time {
+	a := year % 4
+	b := year % 7
+	c := year % 19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	fixed, _ := fixedFromJulian(year, month, day)
+	return dateOfFixed(fixed)
+}
+
+// dateOfFixed is a function to convert a fixed day number into a Gregorian date
+func dateOfFixed(fixed int) time.Time {
+	return time.Unix(int64(fixed-unixEpochFixed)*24*60*60, 0).UTC()
+}
+
+// fixedOfDate is a function to convert a Gregorian date into a fixed day number
+func fixedOfDate(tm time.Time) int {
+	y, m, d := tm.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+	return int(days) + unixEpochFixed
+}
+
+// fixedFromJulian is a function to convert a date of the Julian calendar into a fixed day number,
+// the second returned value is false if the day does not exist (i.e. February 29 in a non-leap year)
+func fixedFromJulian(year int, month int, day int) (int, bool) {
+	leap := year%4 == 0
+	if month == 2 && day == 29 && !leap {
+		return 0, false
+	}
+	correction := 0
+	if month > 2 {
+		correction = -2
+		if leap {
+			correction = -1
+		}
+	}
+	return julianEpoch - 1 + 365*(year-1) + floorDiv(year-1, 4) + floorDiv(367*month-362, 12) + correction + day, true
+}
+
+// fixedFromIslamic is a function to convert a date of the tabular Islamic calendar into a fixed day number:
+// odd months have 30 days, even months have 29 days except the last month of 11 leap years in a 30-year cycle
+func fixedFromIslamic(year int, month int, day int) (int, bool) {
+	length := 30 - (month+1)%2
+	if month == 12 && floorMod(14+11*year, 30) < 11 {
+		length = 30
+	}
+	if year < 1 || day > length {
+		return 0, false
+	}
+	return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1, true
+}
+
+// fixedFromHebrew is a function to convert a date of the Hebrew calendar into a fixed day number.
+// Months are counted from Nisan, Adar stands for Adar II in leap years (as Purim does).
+func fixedFromHebrew(year int, month int, day int) (int, bool) {
+	if month == hebrewAdar && hebrewLeapYear(year) {
+		month = hebrewAdarII
+	}
+	if day > hebrewMonthLength(year, month) {
+		return 0, false
+	}
+	fixed := hebrewNewYear(year) + day - 1
+	if month < hebrewTishrei {
+		for m := hebrewTishrei; m <= hebrewLastMonth(year); m++ {
+			fixed += hebrewMonthLength(year, m)
+		}
+		for m := 1; m < month; m++ {
+			fixed += hebrewMonthLength(year, m)
+		}
+	} else {
+		for m := hebrewTishrei; m < month; m++ {
+			fixed += hebrewMonthLength(year, m)
+		}
+	}
+	return fixed, true
+}
+
+// hebrewLeapYear is a function to check the Hebrew year has 13 months, 7 years of a 19-year cycle do
+func hebrewLeapYear(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+// hebrewLastMonth is a function to give the number of the last month of the Hebrew year, Adar or Adar II
+func hebrewLastMonth(year int) int {
+	if hebrewLeapYear(year) {
+		return hebrewAdarII
+	}
+	return hebrewAdar
+}
+
+// hebrewElapsedDays is a function to count days from the epoch to the molad of Tishrei of the Hebrew year,
+// postponed by a day if it would fall on Sunday, Wednesday or Friday
+func hebrewElapsedDays(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	days := 29*months + floorDiv(parts, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewNewYear is a function to give the fixed day of the 1st of Tishrei of the Hebrew year,
+// postponed further to keep the length of the years valid
+func hebrewNewYear(year int) int {
+	delay := 0
+	previous, current, next := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+	if next-current == 356 {
+		delay = 2
+	} else if current-previous == 382 {
+		delay = 1
+	}
+	return hebrewEpoch + current + delay
+}
+
+// hebrewMonthLength is a function to give the number of days of the month of the Hebrew year
+func hebrewMonthLength(year int, month int) int {
+	days := hebrewNewYear(year+1) - hebrewNewYear(year)
+	switch {
+	case month == 2 || month == 4 || month == 6 || month == 10 || month == hebrewAdarII:
+		return 29
+	case month == hebrewAdar && !hebrewLeapYear(year):
+		return 29
+	case month == 8 && days%10 != 5: // Cheshvan is long in years of 355 and 385 days only
+		return 29
+	case month == 9 && days%10 == 3: // Kislev is short in years of 353 and 383 days
+		return 29
+	default:
+		return 30
+	}
+}
+
+// floorDiv is a function to divide integers rounding towards negative infinity
+func floorDiv(a int, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod is a function to give the remainder of floorDiv, it has the sign of the divisor
+func floorMod(a int, b int) int {
+	return a - b*floorDiv(a, b)
+}
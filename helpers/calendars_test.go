@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalendarDates(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+		monthDay string
+		year     int
+		want     []time.Time
+	}{
+		{"Gregorian", CalendarGregorian, "12-25", 2026, []time.Time{date(2026, time.December, 25)}},
+		{"Orthodox Christmas", CalendarJulian, "12-25", 2026, []time.Time{date(2026, time.January, 7)}},
+		{"Julian leap day", CalendarJulian, "02-29", 2026, nil},
+		{"Rosh Hashanah", CalendarHebrew, "07-01", 2025, []time.Time{date(2025, time.September, 23)}},
+		{"Hanukkah", CalendarHebrew, "09-25", 2025, []time.Time{date(2025, time.December, 15)}},
+		{"Passover", CalendarHebrew, "01-15", 2026, []time.Time{date(2026, time.April, 2)}},
+		{"Purim in a leap year", CalendarHebrew, "12-14", 2024, []time.Time{date(2024, time.March, 24)}},
+		{"Ramadan", CalendarIslamic, "09-01", 2026, []time.Time{date(2026, time.February, 18)}},
+		{"Eid al-Fitr", CalendarIslamic, "10-01", 2025, []time.Time{date(2025, time.March, 31)}},
+		{"Islamic New Year twice a year", CalendarIslamic, "01-01", 2008, []time.Time{date(2008, time.January, 10), date(2008, time.December, 29)}},
+		{"Chinese New Year", CalendarChinese, "01-01", 2026, []time.Time{date(2026, time.February, 17)}},
+		{"Chinese New Year 2033", CalendarChinese, "01-01", 2033, []time.Time{date(2033, time.January, 31)}},
+		{"Chinese New Year 2034", CalendarChinese, "01-01", 2034, []time.Time{date(2034, time.February, 19)}},
+		{"Mid-Autumn after a leap month", CalendarChinese, "08-15", 2023, []time.Time{date(2023, time.September, 29)}},
+		{"Dragon Boat Festival", CalendarChinese, "05-05", 2020, []time.Time{date(2020, time.June, 25)}},
+		{"Chinese New Year's Eve", CalendarChinese, "12-29", 2025, []time.Time{date(2025, time.January, 28)}},
+		{"no 30th of a short month", CalendarChinese, "12-30", 2025, nil},
+		{"invalid day", CalendarChinese, "13-01", 2025, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalendarDates(tt.calendar, tt.monthDay, tt.year); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CalendarDates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJulianEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{2023, date(2023, time.April, 16)},
+		{2024, date(2024, time.May, 5)},
+		{2025, date(2025, time.April, 20)},
+		{2026, date(2026, time.April, 12)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Format("2006-01-02"), func(t *testing.T) {
+			if got := JulianEaster(tt.year); !got.Equal(tt.want) {
+				t.Errorf("JulianEaster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
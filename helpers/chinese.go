@@ -0,0 +1,182 @@
+package helpers
+
+import (
+	"math"
+	"time"
+)
+
+// The Chinese calendar is computed from astronomical new moons and solar terms observed in China (UTC+8),
+// using the low accuracy algorithms of Jean Meeus' "Astronomical Algorithms": they are good to a few minutes
+// from 1900 to 2100, so a new moon or a solar term within minutes of midnight may be off by a day.
+const (
+	synodicMonth = 29.530588861 // mean days from a new moon to the next one
+	fixedJD      = 1721424.5    // the Julian day of the start of the fixed day 0
+	chinaOffset  = 8.0 / 24     // the time zone of China in days
+)
+
+// newMoon is a function to calculate the Julian day of the k-th new moon since January 6, 2000
+func newMoon(k float64) float64 {
+	t := k / 1236.85
+	jde := 2451550.09766 + synodicMonth*k + 0.00015437*t*t - 0.000000150*t*t*t + 0.00000000073*t*t*t*t
+	e := 1 - 0.002516*t - 0.0000074*t*t
+	m := radians(2.5534 + 29.10535670*k - 0.0000014*t*t - 0.00000011*t*t*t)
+	mm := radians(201.5643 + 385.81693528*k + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t)
+	f := radians(160.7108 + 390.67050284*k - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t)
+	omega := radians(124.7746 - 1.56375588*k + 0.0020672*t*t + 0.00000215*t*t*t)
+
+	jde += -0.40720*math.Sin(mm) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mm) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mm-m) -
+		0.00514*e*math.Sin(mm+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mm-2*f) -
+		0.00057*math.Sin(mm+2*f) +
+		0.00056*e*math.Sin(2*mm+m) -
+		0.00042*math.Sin(3*mm) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mm-m) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(mm+2*m) +
+		0.00004*math.Sin(2*mm-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mm+m-2*f) +
+		0.00003*math.Sin(2*mm+2*f) -
+		0.00003*math.Sin(mm+m+2*f) +
+		0.00003*math.Sin(mm-m+2*f) -
+		0.00002*math.Sin(mm-m-2*f) -
+		0.00002*math.Sin(3*mm+m) +
+		0.00002*math.Sin(4*mm)
+
+	return jde
+}
+
+// solarLongitude is a function to calculate the apparent longitude of the Sun in degrees at the Julian day
+func solarLongitude(jd float64) float64 {
+	t := (jd - 2451545) / 36525
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := radians(357.52911 + 35999.05029*t - 0.0001537*t*t)
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) + (0.019993-0.000101*t)*math.Sin(2*m) + 0.000289*math.Sin(3*m)
+	omega := radians(125.04 - 1934.136*t)
+	return math.Mod(math.Mod(l0+c-0.00569-0.00478*math.Sin(omega), 360)+360, 360)
+}
+
+// radians is a function to convert degrees into radians
+func radians(degrees float64) float64 {
+	return math.Mod(degrees, 360) * math.Pi / 180
+}
+
+// chinaDay is a function to give the fixed day the Julian day falls on in China
+func chinaDay(jd float64) int {
+	return int(math.Floor(jd - fixedJD + chinaOffset))
+}
+
+// chinaMidnight is a function to give the Julian day of the start of the fixed day in China
+func chinaMidnight(day int) float64 {
+	return float64(day) + fixedJD - chinaOffset
+}
+
+// newMoonOnOrAfter is a function to give the first day in China since the given one a new moon happens on
+func newMoonOnOrAfter(day int) int {
+	k := math.Floor((chinaMidnight(day)-2451550.09766)/synodicMonth) - 1
+	for ; ; k++ {
+		if d := chinaDay(newMoon(k)); d >= day {
+			return d
+		}
+	}
+}
+
+// newMoonBefore is a function to give the last day in China before the given one a new moon happens on
+func newMoonBefore(day int) int {
+	k := math.Floor((chinaMidnight(day)-2451550.09766)/synodicMonth) + 1
+	for ; ; k-- {
+		if d := chinaDay(newMoon(k)); d < day {
+			return d
+		}
+	}
+}
+
+// majorSolarTerm is a function to give the index of the last major solar term (a multiple of 30 degrees
+// of the solar longitude) passed at the start of the day in China
+func majorSolarTerm(day int) int {
+	return int(solarLongitude(chinaMidnight(day)) / 30)
+}
+
+// noMajorSolarTerm is a function to check the month starting on the day has no major solar term,
+// the first such month of a year with 13 months is the leap month
+func noMajorSolarTerm(day int) bool {
+	return majorSolarTerm(day) == majorSolarTerm(newMoonOnOrAfter(day+1))
+}
+
+// winterSolsticeOnOrBefore is a function to give the last day in China on or before the given one
+// the winter solstice (the solar longitude of 270 degrees) happens on
+func winterSolsticeOnOrBefore(day int) int {
+	jd := chinaMidnight(day + 1)
+	approx := jd - math.Mod(solarLongitude(jd)-270+360, 360)*365.242189/360
+	for d := chinaDay(approx) - 1; ; d++ {
+		if l := solarLongitude(chinaMidnight(d + 1)); l >= 270 && l < 300 {
+			return d
+		}
+	}
+}
+
+// chineseNewYearInSui is a function to give the Chinese New Year of the year from the winter solstice
+// on or before the day to the next one: the second new moon after the solstice,
+// or the third one if a leap month comes between them.
+func chineseNewYearInSui(day int) int {
+	s1 := winterSolsticeOnOrBefore(day)
+	s2 := winterSolsticeOnOrBefore(s1 + 370)
+	m12 := newMoonOnOrAfter(s1 + 1)
+	m13 := newMoonOnOrAfter(m12 + 1)
+	nextM11 := newMoonBefore(s2 + 1)
+	if math.Round(float64(nextM11-m12)/synodicMonth) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+		return newMoonOnOrAfter(m13 + 1)
+	}
+	return m13
+}
+
+// chineseNewYear is a function to give the fixed day of the Chinese New Year in the Gregorian year
+func chineseNewYear(year int) int {
+	// The Chinese New Year falls between January 21 and February 20
+	day := fixedOfDate(time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC))
+	if ny := chineseNewYearInSui(day); ny <= day {
+		return ny
+	}
+	return chineseNewYearInSui(day - 180)
+}
+
+// fixedFromChinese is a function to convert a day of the Chinese year starting in the Gregorian year into a fixed day.
+// Leap months are skipped, i.e. the day falls in the regular month; the second returned value is false
+// if the month has less days.
+func fixedFromChinese(year int, month int, day int) (int, bool) {
+	start, end := chineseNewYear(year), chineseNewYear(year+1)
+	var months []int
+	for m := start; m < end; m = newMoonOnOrAfter(m + 1) {
+		months = append(months, m)
+	}
+	if len(months) == 13 {
+		for i := 1; i < len(months); i++ {
+			if noMajorSolarTerm(months[i]) {
+				months = append(months[:i], months[i+1:]...)
+				break
+			}
+		}
+	}
+	if month > len(months) {
+		return 0, false
+	}
+	next := end
+	if month < len(months) {
+		next = months[month]
+	}
+	// A regular month followed by a leap month ends where the leap month starts
+	if m := newMoonOnOrAfter(months[month-1] + 1); m < next {
+		next = m
+	}
+	if months[month-1]+day > next {
+		return 0, false
+	}
+	return months[month-1] + day - 1, true
+}
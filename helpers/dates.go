@@ -15,3 +15,119 @@ func GetMonthDay(tm time.Time, in int) string {
 
 	return fmt.Sprintf("%02d-%02d", month, day)
 }
+
+// Rules of observing events falling on a weekend on a weekday instead
+const (
+	ObserveNone           = "none"            // observe on the day itself
+	ObserveNextMonday     = "next-monday"     // observe on the following Monday
+	ObserveNearestWeekday = "nearest-weekday" // observe Saturdays on the Friday before and Sundays on the Monday after
+)
+
+// Observances is a list of supported rules of observing events falling on a weekend
+var Observances = []string{ObserveNone, ObserveNextMonday, ObserveNearestWeekday}
+
+// KnownObservance is a helper function to check the rule of observing events falling on a weekend is supported,
+// an empty rule stands for none
+func KnownObservance(rule string) bool {
+	if rule == "" {
+		return true
+	}
+	for _, known := range Observances {
+		if rule == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ObservedWeekday is a helper function to return the date an event falling on the given date is observed on
+// according to the rule, the date itself unless it falls on a weekend
+func ObservedWeekday(tm time.Time, rule string) time.Time {
+	switch {
+	case rule == ObserveNextMonday && tm.Weekday() == time.Saturday:
+		return tm.AddDate(0, 0, 2)
+	case rule == ObserveNextMonday && tm.Weekday() == time.Sunday:
+		return tm.AddDate(0, 0, 1)
+	case rule == ObserveNearestWeekday && tm.Weekday() == time.Saturday:
+		return tm.AddDate(0, 0, -1)
+	case rule == ObserveNearestWeekday && tm.Weekday() == time.Sunday:
+		return tm.AddDate(0, 0, 1)
+	default:
+		return tm
+	}
+}
+
+// IsBusinessDay is a helper function to check the date is a weekday
+func IsBusinessDay(tm time.Time) bool {
+	return tm.Weekday() != time.Saturday && tm.Weekday() != time.Sunday
+}
+
+// BusinessDaysBetween is a helper function to count business days (Monday to Friday) from one date to another:
+// the days after the first date up to the second one inclusive, zero if the second date is not after the first one.
+// The time of the day and the time zone of each date are ignored.
+func BusinessDaysBetween(from time.Time, to time.Time) int {
+	days := DaysBetween(from, to)
+	if days <= 0 {
+		return 0
+	}
+	y, m, d := from.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	count := days / 7 * 5
+	for i := days/7*7 + 1; i <= days; i++ {
+		if IsBusinessDay(start.AddDate(0, 0, i)) {
+			count++
+		}
+	}
+	return count
+}
+
+// DateOfMonthDay is a helper function to return the date of the "<month>-<day>" string in the given year.
+// The second returned value is false if the string is malformed or the day does not exist in that year,
+// e.g. "02-29" in a non-leap year.
+func DateOfMonthDay(monthDay string, year int) (time.Time, bool) {
+	var month, day int
+	if n, err := fmt.Sscanf(monthDay, "%02d-%02d", &month, &day); err != nil || n != 2 || len(monthDay) != 5 {
+		return time.Time{}, false
+	}
+	tm := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if tm.Month() != time.Month(month) || tm.Day() != day {
+		return time.Time{}, false
+	}
+	return tm, true
+}
+
+// Policies of observing events of February 29 in non-leap years
+const (
+	LeapDayFeb28 = "feb28" // observe on February 28
+	LeapDayMar1  = "mar1"  // observe on March 1
+	LeapDaySkip  = "skip"  // observe in leap years only
+)
+
+// LeapDayPolicies is a list of supported policies of observing events of February 29 in non-leap years
+var LeapDayPolicies = []string{LeapDayFeb28, LeapDayMar1, LeapDaySkip}
+
+// ObservedMonthDay is a helper function to return the date the "<month>-<day>" string is observed on in the given year:
+// it is the same as DateOfMonthDay except for "02-29" in non-leap years, which is observed according to the policy.
+func ObservedMonthDay(monthDay string, year int, leapDay string) (time.Time, bool) {
+	if tm, ok := DateOfMonthDay(monthDay, year); ok || monthDay != "02-29" {
+		return tm, ok
+	}
+	switch leapDay {
+	case LeapDayFeb28:
+		return time.Date(year, time.February, 28, 0, 0, 0, 0, time.UTC), true
+	case LeapDayMar1:
+		return time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// DaysBetween is a helper function to count calendar days from one date to another,
+// the time of the day and the time zone of each date are ignored.
+func DaysBetween(from time.Time, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	start := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
@@ -57,3 +57,83 @@ func TestGetDayMonth(t *testing.T) {
 		})
 	}
 }
+
+// Verify the observed date of February 29 and other days in leap and non-leap years for every policy
+func TestObservedMonthDay(t *testing.T) {
+	tests := []struct {
+		name     string
+		monthDay string
+		year     int
+		leapDay  string
+		want     string
+	}{
+		{"leap year, Feb 28 policy", "02-29", 2024, LeapDayFeb28, "2024-02-29"},
+		{"leap year, skip policy", "02-29", 2024, LeapDaySkip, "2024-02-29"},
+		{"non-leap year, Feb 28 policy", "02-29", 2023, LeapDayFeb28, "2023-02-28"},
+		{"non-leap year, Mar 1 policy", "02-29", 2023, LeapDayMar1, "2023-03-01"},
+		{"non-leap year, skip policy", "02-29", 2023, LeapDaySkip, ""},
+		{"other days are not affected", "03-01", 2023, LeapDayFeb28, "2023-03-01"},
+		{"impossible days are not observed", "02-30", 2023, LeapDayMar1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if tm, ok := ObservedMonthDay(tt.monthDay, tt.year, tt.leapDay); ok {
+				got = tm.Format("2006-01-02")
+			}
+			if got != tt.want {
+				t.Errorf("ObservedMonthDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Verify the days events falling on a weekend are observed on for every rule
+func TestObservedWeekday(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		rule string
+		want string
+	}{
+		{"Saturday, next Monday", date(2026, time.July, 4), ObserveNextMonday, "2026-07-06"},
+		{"Sunday, next Monday", date(2027, time.July, 4), ObserveNextMonday, "2027-07-05"},
+		{"Saturday, nearest weekday", date(2026, time.July, 4), ObserveNearestWeekday, "2026-07-03"},
+		{"Sunday, nearest weekday", date(2027, time.July, 4), ObserveNearestWeekday, "2027-07-05"},
+		{"Saturday, to the previous year", date(2028, time.January, 1), ObserveNearestWeekday, "2027-12-31"},
+		{"Saturday, none", date(2026, time.July, 4), ObserveNone, "2026-07-04"},
+		{"weekday", date(2025, time.July, 4), ObserveNextMonday, "2025-07-04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ObservedWeekday(tt.date, tt.rule).Format("2006-01-02"); got != tt.want {
+				t.Errorf("ObservedWeekday() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Verify business days are counted from one date to another
+func TestBusinessDaysBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want int
+	}{
+		{"same day", date(2026, time.October, 16), date(2026, time.October, 16), 0},
+		{"Friday to Monday", date(2026, time.October, 16), date(2026, time.October, 19), 1},
+		{"Saturday to Monday", date(2026, time.October, 17), date(2026, time.October, 19), 1},
+		{"Monday to Saturday", date(2026, time.October, 19), date(2026, time.October, 24), 4},
+		{"Wednesday to Wednesday in two weeks", date(2026, time.October, 14), date(2026, time.October, 28), 10},
+		{"Thursday to Tuesday in two weeks", date(2026, time.October, 15), date(2026, time.October, 27), 8},
+		{"backwards", date(2026, time.October, 19), date(2026, time.October, 16), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BusinessDaysBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("BusinessDaysBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
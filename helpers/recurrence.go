@@ -0,0 +1,240 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	easterRule  = regexp.MustCompile(`^easter\s*(?:([+-])\s*(\d+))?$`)
+	weekdayRule = regexp.MustCompile(`^(\S+)\s+([a-z]+)\s+(?:of\s+([a-z]+(?:\s+month)?)|(monthly))$`)
+	fixedRule   = regexp.MustCompile(`^([a-z]+)\s+(\d{1,2})(?:\s*,?\s*if\s+([a-z]+)\s+then\s+([a-z]+)\s+(\d{1,2}))?$`)
+
+	ordinals = map[string]int{
+		"1st": 1, "first": 1,
+		"2nd": 2, "second": 2,
+		"3rd": 3, "third": 3,
+		"4th": 4, "fourth": 4,
+		"5th": 5, "fifth": 5,
+		"last": -1,
+	}
+)
+
+// Easter is a function to calculate the date of Easter Sunday (Western churches) in the given year,
+// it implements the anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// NthWeekday is a function to find the n-th weekday of the month, e.g. the 2nd Sunday of May.
+// Negative n counts from the end of the month, i.e. -1 is the last weekday of the month.
+// The second returned value is false if the month has no such day (e.g. the 5th Monday).
+func NthWeekday(year int, month time.Month, weekday time.Weekday, n int) (time.Time, bool) {
+	if n > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		shift := (int(weekday) - int(first.Weekday()) + 7) % 7
+		tm := first.AddDate(0, 0, shift+7*(n-1))
+		return tm, tm.Month() == month
+	}
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	shift := (int(last.Weekday()) - int(weekday) + 7) % 7
+	tm := last.AddDate(0, 0, -shift+7*(n+1))
+	return tm, tm.Month() == month
+}
+
+// Recurrence is a struct to keep a parsed recurrence rule
+type Recurrence struct {
+	Easter  bool         // the rule is relative to Easter Sunday
+	Offset  int          // days after (or before, if negative) Easter Sunday
+	N       int          // n-th weekday of the month, -1 for the last one
+	Weekday time.Weekday // weekday of the month, or the weekday a fixed date is moved on
+	Month   time.Month   // month of the year, zero for monthly rules
+	Day     int          // day of the month of a fixed date rule
+	Moved   bool         // the fixed date is moved to MovedTo when it falls on Weekday
+	MovedTo time.Time    // month and day the fixed date is moved to, the year is ignored
+}
+
+// ParseRecurrence is a function to parse a recurrence rule, supported rules (case-insensitive) are:
+//   - "<n> <weekday> of <month>", e.g. "2nd Sunday of May", "last Thursday of November";
+//   - "<n> <weekday> monthly" or "<n> <weekday> of every month", e.g. "last Friday monthly";
+//   - "Easter" with an optional offset in days, e.g. "Easter+1", "Easter-2";
+//   - "<month> <day>" with an optional move when it falls on a weekday, e.g. "April 27, if Sunday then April 26".
+//
+// Here <n> is one of 1st..5th, first..fifth or last.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	var r Recurrence
+	normalized := strings.Join(strings.Fields(strings.ToLower(rule)), " ")
+
+	if m := easterRule.FindStringSubmatch(normalized); m != nil {
+		r.Easter = true
+		if m[2] != "" {
+			r.Offset, _ = strconv.Atoi(m[2])
+			if m[1] == "-" {
+				r.Offset = -r.Offset
+			}
+		}
+		return r, nil
+	}
+
+	if m := fixedRule.FindStringSubmatch(normalized); m != nil {
+		return parseFixedRule(rule, m)
+	}
+
+	m := weekdayRule.FindStringSubmatch(normalized)
+	if m == nil {
+		return r, fmt.Errorf(`unknown recurrence rule "%s"`, rule)
+	}
+	var ok bool
+	if r.N, ok = ordinals[m[1]]; !ok {
+		return r, fmt.Errorf(`unknown ordinal "%s" in recurrence rule "%s"`, m[1], rule)
+	}
+	if r.Weekday, ok = ParseWeekday(m[2]); !ok {
+		return r, fmt.Errorf(`unknown weekday "%s" in recurrence rule "%s"`, m[2], rule)
+	}
+	if m[4] == "" && m[3] != "every month" {
+		if r.Month, ok = ParseMonth(m[3]); !ok {
+			return r, fmt.Errorf(`unknown month "%s" in recurrence rule "%s"`, m[3], rule)
+		}
+	}
+
+	return r, nil
+}
+
+// parseFixedRule is a function to parse the submatches of a fixed date rule, e.g. "April 27, if Sunday then April 26"
+func parseFixedRule(rule string, m []string) (Recurrence, error) {
+	var r Recurrence
+	var ok bool
+	if r.Month, r.Day, ok = parseDay(m[1], m[2]); !ok {
+		return r, fmt.Errorf(`unknown day "%s %s" in recurrence rule "%s"`, m[1], m[2], rule)
+	}
+	if m[3] == "" {
+		return r, nil
+	}
+	r.Moved = true
+	if r.Weekday, ok = ParseWeekday(m[3]); !ok {
+		return r, fmt.Errorf(`unknown weekday "%s" in recurrence rule "%s"`, m[3], rule)
+	}
+	month, day, ok := parseDay(m[4], m[5])
+	if !ok {
+		return r, fmt.Errorf(`unknown day "%s %s" in recurrence rule "%s"`, m[4], m[5], rule)
+	}
+	r.MovedTo = time.Date(0, month, day, 0, 0, 0, 0, time.UTC)
+	return r, nil
+}
+
+// parseDay is a function to parse a month name and a day of the month, the day must exist in a leap year
+func parseDay(monthName string, dayNumber string) (time.Month, int, bool) {
+	month, ok := ParseMonth(monthName)
+	day, err := strconv.Atoi(dayNumber)
+	if !ok || err != nil {
+		return month, day, false
+	}
+	tm := time.Date(2000, month, day, 0, 0, 0, 0, time.UTC)
+	return month, day, day >= 1 && tm.Month() == month
+}
+
+// Resolve is a method to calculate the dates the recurrence rule resolves into in the given year,
+// months without the requested day (e.g. the 5th Monday, February 29 in a non-leap year) are skipped.
+func (r Recurrence) Resolve(year int) []time.Time {
+	if r.Easter {
+		return []time.Time{Easter(year).AddDate(0, 0, r.Offset)}
+	}
+	if r.Day > 0 {
+		tm := time.Date(year, r.Month, r.Day, 0, 0, 0, 0, time.UTC)
+		if tm.Month() != r.Month {
+			return nil
+		}
+		if r.Moved && tm.Weekday() == r.Weekday {
+			tm = time.Date(year, r.MovedTo.Month(), r.MovedTo.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		return []time.Time{tm}
+	}
+
+	months := []time.Month{r.Month}
+	if r.Month == 0 {
+		months = nil
+		for month := time.January; month <= time.December; month++ {
+			months = append(months, month)
+		}
+	}
+
+	var dates []time.Time
+	for _, month := range months {
+		if tm, ok := NthWeekday(year, month, r.Weekday, r.N); ok {
+			dates = append(dates, tm)
+		}
+	}
+
+	return dates
+}
+
+// Supports is a method to check the recurrence rule can be applied in the calendar:
+// every rule is supported in the Gregorian calendar, Easter-based rules in the Julian one as well
+func (r Recurrence) Supports(calendar string) bool {
+	return calendar == "" || calendar == CalendarGregorian || (calendar == CalendarJulian && r.Easter)
+}
+
+// ResolveIn is a method to calculate the Gregorian dates the recurrence rule applied in the calendar resolves into
+// in the given year, Easter-based rules in the Julian calendar follow the Easter of Eastern Orthodox churches.
+func (r Recurrence) ResolveIn(calendar string, year int) []time.Time {
+	switch {
+	case !r.Supports(calendar):
+		return nil
+	case calendar == CalendarJulian:
+		return []time.Time{JulianEaster(year).AddDate(0, 0, r.Offset)}
+	default:
+		return r.Resolve(year)
+	}
+}
+
+// ResolveRecurrence is a function to calculate the dates a recurrence rule resolves into in the given year,
+// see ParseRecurrence for the supported rules.
+func ResolveRecurrence(rule string, year int) ([]time.Time, error) {
+	r, err := ParseRecurrence(rule)
+	if err != nil {
+		return nil, err
+	}
+	return r.Resolve(year), nil
+}
+
+// ParseWeekday is a function to convert an English weekday name (full or 3-letter) into time.Weekday
+func ParseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(name)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		full := strings.ToLower(weekday.String())
+		if name == full || name == full[:3] {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// ParseMonth is a function to convert an English month name (full or 3-letter) into time.Month
+func ParseMonth(name string) (time.Month, bool) {
+	name = strings.ToLower(name)
+	for month := time.January; month <= time.December; month++ {
+		full := strings.ToLower(month.String())
+		if name == full || name == full[:3] {
+			return month, true
+		}
+	}
+	return time.January, false
+}
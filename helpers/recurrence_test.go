@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{2000, date(2000, time.April, 23)},
+		{2019, date(2019, time.April, 21)},
+		{2022, date(2022, time.April, 17)},
+		{2024, date(2024, time.March, 31)},
+		{2025, date(2025, time.April, 20)},
+		{2038, date(2038, time.April, 25)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Format("2006-01-02"), func(t *testing.T) {
+			if got := Easter(tt.year); !got.Equal(tt.want) {
+				t.Errorf("Easter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		year    int
+		want    []time.Time
+		wantErr bool
+	}{
+		{"Mother's Day", "2nd Sunday of May", 2022, []time.Time{date(2022, time.May, 8)}, false},
+		{"Thanksgiving", "4th Thursday of November", 2022, []time.Time{date(2022, time.November, 24)}, false},
+		{"last weekday of the month", "Last Monday of May", 2022, []time.Time{date(2022, time.May, 30)}, false},
+		{"words and abbreviations", "first  sun of jan", 2023, []time.Time{date(2023, time.January, 1)}, false},
+		{"no such day in the month", "5th Monday of February", 2022, nil, false},
+		{"Easter", "Easter", 2022, []time.Time{date(2022, time.April, 17)}, false},
+		{"Easter Monday", "Easter+1", 2022, []time.Time{date(2022, time.April, 18)}, false},
+		{"Good Friday", "Easter - 2", 2022, []time.Time{date(2022, time.April, 15)}, false},
+		{
+			"monthly",
+			"last Friday monthly",
+			2022,
+			[]time.Time{
+				date(2022, time.January, 28), date(2022, time.February, 25), date(2022, time.March, 25),
+				date(2022, time.April, 29), date(2022, time.May, 27), date(2022, time.June, 24),
+				date(2022, time.July, 29), date(2022, time.August, 26), date(2022, time.September, 30),
+				date(2022, time.October, 28), date(2022, time.November, 25), date(2022, time.December, 30),
+			},
+			false,
+		},
+		{
+			"monthly skipping months without the day",
+			"5th Saturday of every month",
+			2022,
+			[]time.Time{
+				date(2022, time.January, 29), date(2022, time.April, 30), date(2022, time.July, 30),
+				date(2022, time.October, 29), date(2022, time.December, 31),
+			},
+			false,
+		},
+		{"fixed date", "April 27", 2025, []time.Time{date(2025, time.April, 27)}, false},
+		{"fixed date not moved", "April 27, if Sunday then April 26", 2024, []time.Time{date(2024, time.April, 27)}, false},
+		{"fixed date moved", "April 27, if Sunday then April 26", 2025, []time.Time{date(2025, time.April, 26)}, false},
+		{"fixed leap day", "Feb 29", 2023, nil, false},
+		{"unknown ordinal", "6th Sunday of May", 2022, nil, true},
+		{"impossible fixed date", "February 30", 2022, nil, true},
+		{"impossible moved date", "April 27, if Sunday then April 31", 2022, nil, true},
+		{"unknown weekday", "2nd Funday of May", 2022, nil, true},
+		{"unknown month", "2nd Sunday of Maytember", 2022, nil, true},
+		{"unknown rule", "every day", 2022, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveRecurrence(tt.rule, tt.year)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveRecurrence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveRecurrence() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package holidays
+
+import (
+	"clingo/helpers"
+	"clingo/structs"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Remind is the number of days to remind of public holidays in advance
+const Remind = 1
+
+// holiday is a struct to keep a public holiday: either on a fixed "MM-DD" date or with a recurrence rule
+// (see helpers.ParseRecurrence), the year is the one it is counted from, zero for holidays not counted in years,
+// holidays falling on a weekend are observed on a weekday according to the observance rule, if any
+type holiday struct {
+	Date       string
+	Recurrence string
+	Year       int
+	Name       string
+	Observe    string
+}
+
+// calendars is a map of ISO 3166-1 country codes to the public holidays of the country,
+// the dates are computed every year, so no network access is needed
+var calendars = map[string][]holiday{
+	"DE": {
+		{Date: "01-01", Name: "New Year's Day"},
+		{Recurrence: "Easter-2", Name: "Good Friday"},
+		{Recurrence: "Easter+1", Name: "Easter Monday"},
+		{Date: "05-01", Year: 1919, Name: "Labour Day"},
+		{Recurrence: "Easter+39", Name: "Ascension Day"},
+		{Recurrence: "Easter+50", Name: "Whit Monday"},
+		{Date: "10-03", Year: 1990, Name: "German Unity Day"},
+		{Date: "12-25", Name: "Christmas Day"},
+		{Date: "12-26", Name: "Second Day of Christmas"},
+	},
+	"GB": {
+		{Date: "01-01", Name: "New Year's Day", Observe: helpers.ObserveNextMonday},
+		{Recurrence: "Easter-2", Name: "Good Friday"},
+		{Recurrence: "Easter+1", Name: "Easter Monday"},
+		{Recurrence: "1st Monday of May", Year: 1978, Name: "Early May bank holiday"},
+		{Recurrence: "last Monday of May", Year: 1971, Name: "Spring bank holiday"},
+		{Recurrence: "last Monday of August", Year: 1971, Name: "Summer bank holiday"},
+		{Date: "12-25", Name: "Christmas Day", Observe: helpers.ObserveNextMonday},
+		{Date: "12-26", Name: "Boxing Day", Observe: helpers.ObserveNextMonday},
+	},
+	"NL": {
+		{Date: "01-01", Name: "New Year's Day"},
+		{Recurrence: "Easter-2", Name: "Good Friday"},
+		{Recurrence: "Easter", Name: "Easter Sunday"},
+		{Recurrence: "Easter+1", Name: "Easter Monday"},
+		{Recurrence: "April 27, if Sunday then April 26", Year: 2014, Name: "King's Day"},
+		{Date: "05-05", Year: 1945, Name: "Liberation Day"},
+		{Recurrence: "Easter+39", Name: "Ascension Day"},
+		{Recurrence: "Easter+49", Name: "Whit Sunday"},
+		{Recurrence: "Easter+50", Name: "Whit Monday"},
+		{Date: "12-25", Name: "Christmas Day"},
+		{Date: "12-26", Name: "Second Day of Christmas"},
+	},
+	"US": {
+		{Date: "01-01", Name: "New Year's Day", Observe: helpers.ObserveNearestWeekday},
+		{Recurrence: "3rd Monday of January", Year: 1986, Name: "Martin Luther King Jr. Day"},
+		{Recurrence: "3rd Monday of February", Year: 1879, Name: "Washington's Birthday"},
+		{Recurrence: "last Monday of May", Year: 1868, Name: "Memorial Day"},
+		{Date: "06-19", Year: 1865, Name: "Juneteenth", Observe: helpers.ObserveNearestWeekday},
+		{Date: "07-04", Year: 1776, Name: "Independence Day", Observe: helpers.ObserveNearestWeekday},
+		{Recurrence: "1st Monday of September", Year: 1894, Name: "Labor Day"},
+		{Recurrence: "2nd Monday of October", Year: 1937, Name: "Columbus Day"},
+		{Date: "11-11", Year: 1919, Name: "Veterans Day", Observe: helpers.ObserveNearestWeekday},
+		{Recurrence: "4th Thursday of November", Year: 1863, Name: "Thanksgiving Day"},
+		{Date: "12-25", Name: "Christmas Day", Observe: helpers.ObserveNearestWeekday},
+	},
+}
+
+// Countries is a function to list the codes of countries with a bundled holiday calendar
+func Countries() []string {
+	codes := make([]string, 0, len(calendars))
+	for code := range calendars {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Events is a function to give the public holidays of the countries (case-insensitive codes, e.g. "NL", "us")
+// as events of type holiday, labelled with the country they are observed in
+func Events(countries []string) ([]structs.EventMetadata, error) {
+	var list []structs.EventMetadata
+	for _, country := range countries {
+		code := strings.ToUpper(strings.TrimSpace(country))
+		calendar, ok := calendars[code]
+		if !ok {
+			return nil, fmt.Errorf(`no holidays of country "%s", expected one of %s`, country, strings.Join(Countries(), ", "))
+		}
+		for _, h := range calendar {
+			list = append(list, structs.EventMetadata{
+				Date:       h.Date,
+				Recurrence: h.Recurrence,
+				Year:       h.Year,
+				Observe:    h.Observe,
+				Remind:     structs.Remind{Days: Remind},
+				Type:       "holiday",
+				Event:      h.Name,
+				Source:     code + " holidays",
+			})
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,59 @@
+package holidays
+
+import (
+	"clingo/events"
+	"clingo/helpers"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	list, err := Events(Countries())
+	require.NoError(t, err)
+	for _, e := range list {
+		require.Empty(t, events.Problems(e), "%s: %s", e.Source, e.Event)
+		// Holidays not counted in years have no year, rather than an invented one
+		require.NotEqual(t, 1, e.Year, "%s: %s", e.Source, e.Event)
+	}
+
+	_, err = Events([]string{"NL", "XX"})
+	require.Error(t, err, "unknown countries should be rejected")
+}
+
+func TestEventsDates(t *testing.T) {
+	tests := []struct {
+		country string
+		event   string
+		year    int
+		want    time.Time
+	}{
+		{"nl", "King's Day", 2024, time.Date(2024, time.April, 27, 0, 0, 0, 0, time.UTC)},
+		{"nl", "King's Day", 2025, time.Date(2025, time.April, 26, 0, 0, 0, 0, time.UTC)},
+		{"NL", "Ascension Day", 2026, time.Date(2026, time.May, 14, 0, 0, 0, 0, time.UTC)},
+		{"NL", "Whit Monday", 2026, time.Date(2026, time.May, 25, 0, 0, 0, 0, time.UTC)},
+		{"US", "Thanksgiving Day", 2026, time.Date(2026, time.November, 26, 0, 0, 0, 0, time.UTC)},
+		{"US", "Memorial Day", 2026, time.Date(2026, time.May, 25, 0, 0, 0, 0, time.UTC)},
+		{"US", "Independence Day", 2025, time.Date(2025, time.July, 4, 0, 0, 0, 0, time.UTC)},
+		{"US", "Independence Day", 2026, time.Date(2026, time.July, 3, 0, 0, 0, 0, time.UTC)},
+		{"US", "New Year's Day", 2022, time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{"GB", "Boxing Day", 2026, time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC)},
+		{"GB", "Good Friday", 2026, time.Date(2026, time.April, 3, 0, 0, 0, 0, time.UTC)},
+		{"DE", "German Unity Day", 2026, time.Date(2026, time.October, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.event, func(t *testing.T) {
+			list, err := Events([]string{tt.country})
+			require.NoError(t, err)
+			for _, e := range list {
+				if e.Event == tt.event {
+					require.Equal(t, "holiday", e.Type)
+					require.Equal(t, []time.Time{tt.want}, events.Dates(e, tt.year, helpers.LeapDayFeb28))
+					return
+				}
+			}
+			t.Errorf("no holiday %s in %s", tt.event, tt.country)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"clingo/cmd"
 	_ "clingo/weather"
+	_ "time/tzdata" // time zones of events are available even if the system has no time zone database
 
 	"github.com/spf13/cobra"
 )
@@ -0,0 +1,137 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EventMetadata is a struct to store metadata about a personal or public event
+type EventMetadata struct {
+	Date       string `json:"date,omitempty"`
+	Recurrence string `json:"recurrence,omitempty"`
+	Start      string `json:"start,omitempty"` // the date (YYYY-MM-DD) of a one-off event or the first day of a range
+	End        string `json:"end,omitempty"`   // the last day (YYYY-MM-DD) of a range
+	Year       int    `json:"year"`
+	Remind     Remind `json:"remind"`
+	Type       string `json:"type"`
+	Event      string `json:"event"`
+	TZ         string `json:"tz,omitempty"`
+	Calendar   string `json:"calendar,omitempty"` // the calendar the date or the recurrence rule is given in, Gregorian by default
+	Observe    string `json:"observe,omitempty"`  // the rule of observing the event falling on a weekend, none by default
+	// BusinessDays tells the days to remind in advance are business days, reminders are given on business days only
+	BusinessDays bool   `json:"business_days,omitempty"`
+	Source       string `json:"-"` // the events file the event comes from, if events are merged from several files
+}
+
+// Remind is a sub-struct of EventMetadata struct, it is either a number of days to remind every day in advance
+// (a number in JSON, e.g. 3) or a list of exact days in advance to remind on (a list in JSON, e.g. [30, 7, 1])
+type Remind struct {
+	Days    int
+	Offsets []int
+}
+
+// Due is a method to check if the reminder is due the given number of days before the event
+func (r Remind) Due(days int) bool {
+	if len(r.Offsets) == 0 {
+		return days <= r.Days
+	}
+	for _, offset := range r.Offsets {
+		if offset == days {
+			return true
+		}
+	}
+	return false
+}
+
+// Max is a method to give the earliest number of days in advance the reminder is due
+func (r Remind) Max() int {
+	max := r.Days
+	for _, offset := range r.Offsets {
+		if offset > max {
+			max = offset
+		}
+	}
+	return max
+}
+
+// Extend is a method to give the reminder due at least the given number of days in advance:
+// a number of days grows up to it, a list of days gets it as one more day to remind on
+func (r Remind) Extend(days int) Remind {
+	if len(r.Offsets) == 0 {
+		if days > r.Days {
+			r.Days = days
+		}
+		return r
+	}
+	for _, offset := range r.Offsets {
+		if offset == days {
+			return r
+		}
+	}
+	offsets := append([]int{}, r.Offsets...)
+	offsets = append(offsets, days)
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+	return Remind{Offsets: offsets}
+}
+
+// MarshalJSON is a method to write the reminder as a number or as a list of numbers
+func (r Remind) MarshalJSON() ([]byte, error) {
+	if len(r.Offsets) == 0 {
+		return json.Marshal(r.Days)
+	}
+	return json.Marshal(r.Offsets)
+}
+
+// UnmarshalJSON is a method to read the reminder from a number or from a list of numbers
+func (r *Remind) UnmarshalJSON(data []byte) error {
+	*r = Remind{}
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &r.Offsets); err != nil {
+			return err
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(r.Offsets)))
+		return nil
+	}
+	return json.Unmarshal(data, &r.Days)
+}
+
+// String is a method to format the reminder the same way Set parses it
+func (r *Remind) String() string {
+	if len(r.Offsets) == 0 {
+		return strconv.Itoa(r.Days)
+	}
+	offsets := make([]string, len(r.Offsets))
+	for i, offset := range r.Offsets {
+		offsets[i] = strconv.Itoa(offset)
+	}
+	return strings.Join(offsets, ",")
+}
+
+// Set is a method to parse the reminder from a number ("3") or a comma-separated list of numbers ("30,7,1"),
+// it makes Remind usable as a command line flag
+func (r *Remind) Set(value string) error {
+	parts := strings.Split(value, ",")
+	var offsets []int
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf(`invalid reminder "%s", expected a number or a list of numbers`, value)
+		}
+		offsets = append(offsets, n)
+	}
+	if len(parts) == 1 {
+		*r = Remind{Days: offsets[0]}
+		return nil
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+	*r = Remind{Offsets: offsets}
+	return nil
+}
+
+// Type is a method to name the type of the command line flag
+func (r *Remind) Type() string {
+	return "days"
+}